@@ -3,8 +3,10 @@ package repl
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"io"
 
+	"github.com/latiif/lail/pkg/ast"
 	"github.com/latiif/lail/pkg/evaluator/interpretor"
 	"github.com/latiif/lail/pkg/lexer"
 	"github.com/latiif/lail/pkg/object"
@@ -14,6 +16,9 @@ import (
 // prompt is the symbol printed at the beginning of every line
 const prompt = "> "
 
+// width is the number of columns results are fitted in
+const width = 80
+
 // Start starts the interactive REPL
 func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
@@ -32,8 +37,9 @@ func Start(in io.Reader, out io.Writer) {
 
 		interpreted := interpretor.Eval(prog, env)
 
+		// results are shown in their debug representation, e.g. with quoted strings
 		if interpreted != nil {
-			io.WriteString(out, interpreted.Inspect())
+			io.WriteString(out, object.Pretty(interpreted, width))
 			io.WriteString(out, "\n")
 		}
 		print(prompt)
@@ -46,7 +52,15 @@ func printParserErrors(out io.Writer, errors []string) {
 	}
 }
 
-func InterpretFile(context string, in io.Reader, out io.Writer, err io.Writer) {
+var (
+	// ErrParsing is returned when the program has syntax errors
+	ErrParsing = errors.New("parsing failed")
+	// ErrEvaluation is returned when errors were encountered while evaluating the program
+	ErrEvaluation = errors.New("evaluation failed")
+)
+
+// InterpretFile parses and evaluates a whole program read from in
+func InterpretFile(context string, in io.Reader, out io.Writer, err io.Writer) error {
 	scanner := bufio.NewScanner(in)
 	var b bytes.Buffer
 	for scanner.Scan() {
@@ -54,18 +68,63 @@ func InterpretFile(context string, in io.Reader, out io.Writer, err io.Writer) {
 		b.WriteString("\n") // to preserve new lines for token logging
 	}
 
+	prog, parseErr := parse(context, b.String(), err)
+	if parseErr != nil {
+		return parseErr
+	}
+
+	errorCount := interpretor.ErrorCount()
+	printResult(out, interpretor.Eval(prog, object.NewEnv()))
+	if interpretor.ErrorCount() != errorCount {
+		return ErrEvaluation
+	}
+	return nil
+}
+
+// InterpretLines parses program once and evaluates it for every line read from inputs
+// with line and lineNo bound to the line and its 1-based number.
+// The environment is shared between lines, so that values can be accumulated.
+// If print is set, the result of every evaluation is written to out.
+func InterpretLines(program string, inputs []io.Reader, out io.Writer, err io.Writer, print bool) error {
+	prog, parseErr := parse("./", program, err)
+	if parseErr != nil {
+		return parseErr
+	}
+
+	errorCount := interpretor.ErrorCount()
 	e := object.NewEnv()
-	l := lexer.New(b.String())
+	lineNo := int64(0)
+	for _, in := range inputs {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			lineNo++
+			e.Set("line", &object.String{Value: scanner.Text()})
+			e.Set("lineNo", &object.Integer{Value: lineNo})
+			interpreted := interpretor.Eval(prog, e)
+			if print {
+				printResult(out, interpreted)
+			}
+		}
+	}
+	if interpretor.ErrorCount() != errorCount {
+		return ErrEvaluation
+	}
+	return nil
+}
+
+func parse(context string, program string, err io.Writer) (*ast.Program, error) {
+	l := lexer.New(program)
 	p := parser.New(l, context)
 
 	prog := p.ParseProgram()
 	if len(p.Errors()) != 0 {
 		printParserErrors(err, p.Errors())
-		return
+		return nil, ErrParsing
 	}
+	return prog, nil
+}
 
-	interpreted := interpretor.Eval(prog, e)
-
+func printResult(out io.Writer, interpreted object.Object) {
 	if interpreted != nil {
 		io.WriteString(out, interpreted.Inspect())
 		io.WriteString(out, "\n")
@@ -0,0 +1,54 @@
+package repl
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestInterpretLines(t *testing.T) {
+	tests := []struct {
+		program  string
+		inputs   []string
+		print    bool
+		expected string
+	}{
+		{`line + "!"`, []string{"a\nb\n"}, true, "a!\nb!\n"},
+		{"lineNo", []string{"a\nb\n", "c"}, true, "1\n2\n3\n"},
+		{"lineNo", []string{"a\nb\n"}, false, ""},
+		{"lineNo", []string{""}, true, ""},
+		{`let total = lineNo; total * 10`, []string{"x\ny"}, true, "10\n20\n"},
+	}
+
+	for _, tt := range tests {
+		inputs := []io.Reader{}
+		for _, input := range tt.inputs {
+			inputs = append(inputs, strings.NewReader(input))
+		}
+		var out, errOut bytes.Buffer
+		if err := InterpretLines(tt.program, inputs, &out, &errOut, tt.print); err != nil {
+			t.Fatalf("%s: unexpected error: %v, %s", tt.program, err, errOut.String())
+		}
+		if out.String() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.program, out.String(), tt.expected)
+		}
+	}
+}
+
+func TestInterpretLinesErrors(t *testing.T) {
+	tests := []struct {
+		program  string
+		expected error
+	}{
+		{"let = 1", ErrParsing},
+		{"undeclared", ErrEvaluation},
+	}
+
+	for _, tt := range tests {
+		var out, errOut bytes.Buffer
+		if err := InterpretLines(tt.program, []io.Reader{strings.NewReader("a")}, &out, &errOut, true); err != tt.expected {
+			t.Errorf("%s: error wrong. got: %v, want: %v", tt.program, err, tt.expected)
+		}
+	}
+}
@@ -1,35 +1,204 @@
 package cmd
 
 import (
+	"flag"
 	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/latiif/lail/cmd/repl"
+	"github.com/latiif/lail/pkg/evaluator/interpretor"
 )
 
-func execute(args []string) error {
+// argsSeparator separates the files to run from the arguments passed to the scripts
+const argsSeparator = "--"
+
+// options holds the command line flags
+type options struct {
+	expression string         // program given on the command line
+	eachLine   bool           // run the program once per input line
+	printEach  bool           // print the result for each input line
+	allowExec  bool           // permit scripts to run other programs
+	seed       int64          // seed of the random module
+	seeded     bool           // whether a seed was given
+	strict     bool           // disable implicit conversions between types
+	arithmetic arithmeticFlag // behaviour on integer overflow and division by zero
+}
+
+// arithmeticFlag is an interpretor.ArithmeticMode given on the command line
+type arithmeticFlag interpretor.ArithmeticMode
+
+func (a *arithmeticFlag) String() string {
+	return string(*a)
+}
+
+func (a *arithmeticFlag) Set(val string) error {
+	switch mode := interpretor.ArithmeticMode(val); mode {
+	case interpretor.Promote, interpretor.Checked, interpretor.Legacy:
+		*a = arithmeticFlag(mode)
+		return nil
+	}
+	return fmt.Errorf("must be one of %s, %s or %s", interpretor.Promote, interpretor.Checked, interpretor.Legacy)
+}
+
+func parseFlags(args []string) (*options, []string, error) {
+	opts := &options{arithmetic: arithmeticFlag(interpretor.Promote)}
+	flags := flag.NewFlagSet("lail", flag.ContinueOnError)
+	flags.StringVar(&opts.expression, "e", "", "evaluate `program` instead of reading it from a file")
+	flags.BoolVar(&opts.eachLine, "n", false, "run the program once per input line, with line and lineNo bound")
+	flags.BoolVar(&opts.printEach, "p", false, "like -n, but also print the result for each line")
+	flags.BoolVar(&opts.allowExec, "allow-exec", false, "permit scripts to run other programs")
+	flags.Int64Var(&opts.seed, "seed", 0, "seed the random module for reproducible runs")
+	flags.BoolVar(&opts.strict, "strict", false, "disable implicit conversions between the types of operands")
+	flags.Var(&opts.arithmetic, "arithmetic", "behaviour on integer overflow and division by zero: promote, checked or legacy")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: lail [flags] [file ...] [-- args ...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			opts.seeded = true
+		}
+	})
+	positional := flags.Args()
+	// the flag package drops the -- which ends the flags, but it also separates the files from the script arguments
+	if end := len(args) - len(positional); end > 0 && args[end-1] == argsSeparator && !isFlagValue(flags, args[:end-1]) {
+		positional = append([]string{argsSeparator}, positional...)
+	}
+	return opts, positional, nil
+}
+
+// isFlagValue reports whether the argument after args is the value of the flag which args end with, as in -e --
+func isFlagValue(flags *flag.FlagSet, args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	name := strings.TrimLeft(args[len(args)-1], "-")
+	if !strings.HasPrefix(args[len(args)-1], "-") || strings.Contains(name, "=") {
+		return false
+	}
+	f := flags.Lookup(name)
+	if f == nil {
+		return false
+	}
+	boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return !ok || !boolFlag.IsBoolFlag()
+}
+
+func execute(args []string) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			fmt.Println("Fatal issue detected:", r, "\nABORTED!")
+			err = fmt.Errorf("%v", r)
 		}
 	}()
-	if len(args) == 0 {
+	opts, positional, err := parseFlags(args)
+	if err == flag.ErrHelp {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	interpretor.Settings.Sandbox.AllowExec = opts.allowExec
+	interpretor.Settings.Strict = opts.strict
+	interpretor.Settings.Arithmetic = interpretor.ArithmeticMode(opts.arithmetic)
+	if opts.seeded {
+		interpretor.Settings.Random = rand.New(rand.NewSource(opts.seed))
+	}
+
+	if opts.eachLine || opts.printEach {
+		return executeLines(opts, positional)
+	}
+
+	if opts.expression != "" {
+		// there are no files to run, everything after the flags is passed to the program
+		if len(positional) > 0 && positional[0] == argsSeparator {
+			positional = positional[1:]
+		}
+		interpretor.Settings.Args = positional
+		return repl.InterpretFile("./", strings.NewReader(opts.expression), os.Stdout, os.Stderr)
+	}
+
+	files, scriptArgs := splitArgs(positional)
+	interpretor.Settings.Args = scriptArgs
+	if len(files) == 0 {
+		// a program piped into lail is run as a whole rather than prompted line by line
+		if !isTerminal(os.Stdin) {
+			return repl.InterpretFile("./", os.Stdin, os.Stdout, os.Stderr)
+		}
 		repl.Start(os.Stdin, os.Stdout)
-	} else {
-		for _, file := range args {
-			fileHandle, err := os.Open(file)
-			if err != nil {
-				continue
-			}
-			repl.InterpretFile(filepath.Dir(file), fileHandle, os.Stdout, os.Stderr)
-			fileHandle.Close()
+		return nil
+	}
+	for _, file := range files {
+		fileHandle, openErr := os.Open(file)
+		if openErr != nil {
+			fmt.Fprintln(os.Stderr, openErr)
+			err = openErr
+			continue
+		}
+		if runErr := repl.InterpretFile(filepath.Dir(file), fileHandle, os.Stdout, os.Stderr); runErr != nil {
+			err = runErr
+		}
+		fileHandle.Close()
+	}
+	return err
+}
+
+// executeLines runs the program once per line of the input files, or of standard input if none are given.
+// Without -e, the program is the first positional argument.
+func executeLines(opts *options, positional []string) error {
+	program := opts.expression
+	if program == "" {
+		if len(positional) == 0 {
+			return fmt.Errorf("no program given")
+		}
+		program, positional = positional[0], positional[1:]
+	}
+	files, scriptArgs := splitArgs(positional)
+	interpretor.Settings.Args = scriptArgs
+
+	inputs := []io.Reader{}
+	for _, file := range files {
+		fileHandle, err := os.Open(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return err
+		}
+		defer fileHandle.Close()
+		inputs = append(inputs, fileHandle)
+	}
+	if len(inputs) == 0 {
+		inputs = append(inputs, os.Stdin)
+	}
+	return repl.InterpretLines(program, inputs, os.Stdout, os.Stderr, opts.printEach)
+}
+
+// splitArgs splits the command line into files to run and arguments for the scripts
+func splitArgs(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if arg == argsSeparator {
+			return args[:i], args[i+1:]
 		}
 	}
-	return nil
+	return args, []string{}
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
 }
 
-// Executes the program
+// Execute runs the program and returns an error if parsing or evaluation failed
 func Execute() error {
 	return execute(os.Args[1:])
 }
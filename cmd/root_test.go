@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/latiif/lail/pkg/evaluator/interpretor"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		args               []string
+		expectedExpression string
+		expectedEachLine   bool
+		expectedFiles      []string
+		expectedScriptArgs []string
+	}{
+		{[]string{}, "", false, []string{}, []string{}},
+		{[]string{"a.lail", "b.lail"}, "", false, []string{"a.lail", "b.lail"}, []string{}},
+		{[]string{"a.lail", "--", "x", "y"}, "", false, []string{"a.lail"}, []string{"x", "y"}},
+		{[]string{"--", "x", "y"}, "", false, []string{}, []string{"x", "y"}},
+		{[]string{"--strict", "--", "x"}, "", false, []string{}, []string{"x"}},
+		{[]string{"-e", "1 + 1"}, "1 + 1", false, []string{}, []string{}},
+		{[]string{"-e", "args()", "--", "x"}, "args()", false, []string{}, []string{"x"}},
+		{[]string{"-e", "--", "x"}, "--", false, []string{"x"}, []string{}},
+		{[]string{"-e=--", "--", "x"}, "--", false, []string{}, []string{"x"}},
+		{[]string{"-n", "out(line)", "in.txt", "--", "x"}, "", true, []string{"out(line)", "in.txt"}, []string{"x"}},
+	}
+
+	for _, tt := range tests {
+		opts, positional, err := parseFlags(tt.args)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %v", tt.args, err)
+		}
+		if opts.expression != tt.expectedExpression {
+			t.Errorf("%q: expression wrong. got: %q, want: %q", tt.args, opts.expression, tt.expectedExpression)
+		}
+		if opts.eachLine != tt.expectedEachLine {
+			t.Errorf("%q: eachLine wrong. got: %t, want: %t", tt.args, opts.eachLine, tt.expectedEachLine)
+		}
+		files, scriptArgs := splitArgs(positional)
+		if !reflect.DeepEqual(files, tt.expectedFiles) || !reflect.DeepEqual(scriptArgs, tt.expectedScriptArgs) {
+			t.Errorf("%q: got files %q and script args %q, want %q and %q", tt.args, files, scriptArgs, tt.expectedFiles, tt.expectedScriptArgs)
+		}
+	}
+}
+
+func TestParseFlagsErrors(t *testing.T) {
+	for _, args := range [][]string{{"-e"}, {"--arithmetic", "fast"}, {"--unknown"}} {
+		if _, _, err := parseFlags(args); err == nil {
+			t.Errorf("%q: expected an error", args)
+		}
+	}
+}
+
+func TestScriptArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected []string
+	}{
+		{[]string{"-e", "1"}, []string{}},
+		{[]string{"-e", "1", "x", "y"}, []string{"x", "y"}},
+		{[]string{"-e", "1", "--", "x", "y"}, []string{"x", "y"}},
+		{[]string{"-e", "1", "--", "--", "x"}, []string{"--", "x"}},
+	}
+
+	for _, tt := range tests {
+		func() {
+			defer func(previous *interpretor.Config) { interpretor.Settings = previous }(interpretor.Settings)
+			interpretor.Settings = interpretor.DefaultConfig()
+			if err := execute(tt.args); err != nil {
+				t.Fatalf("%q: unexpected error: %v", tt.args, err)
+			}
+			if !reflect.DeepEqual(interpretor.Settings.Args, tt.expected) {
+				t.Errorf("%q: script args wrong. got: %q, want: %q", tt.args, interpretor.Settings.Args, tt.expected)
+			}
+		}()
+	}
+}
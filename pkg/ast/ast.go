@@ -21,9 +21,13 @@ type Expression interface {
 	expressionNode()
 }
 
+// StrictPragma is the string which, as the first statement of a program, opts it into strict mode
+const StrictPragma = "use strict"
+
 // Program represents a program i.e. a slice of statements
 type Program struct {
 	Statements []Statement
+	Strict     bool // whether the program starts with the strict pragma
 }
 
 // TokenLiteral recursively prints literals of the program
@@ -2,6 +2,7 @@ package ast
 
 import (
 	"bytes"
+	"math/big"
 	"strings"
 
 	"github.com/latiif/lail/pkg/token"
@@ -34,6 +35,7 @@ func (es *ExpressionStatement) String() string {
 type IntegerLiteral struct {
 	Token token.Token
 	Value int64
+	Big   *big.Int // set instead of Value for literals that do not fit in an int64
 }
 
 func (il *IntegerLiteral) expressionNode() {}
@@ -47,6 +49,40 @@ func (il *IntegerLiteral) String() string {
 	return il.Token.Literal
 }
 
+// FloatLiteral represents all floating-point values
+type FloatLiteral struct {
+	Token token.Token
+	Value float64
+}
+
+func (fl *FloatLiteral) expressionNode() {}
+
+// TokenLiteral implements the Node interface
+func (fl *FloatLiteral) TokenLiteral() string {
+	return fl.Token.Literal
+}
+
+func (fl *FloatLiteral) String() string {
+	return fl.Token.Literal
+}
+
+// DecimalLiteral represents an exact decimal number, e.g. 12.34d
+type DecimalLiteral struct {
+	Token token.Token
+	Value string // the number without its suffix
+}
+
+func (dl *DecimalLiteral) expressionNode() {}
+
+// TokenLiteral implements the Node interface
+func (dl *DecimalLiteral) TokenLiteral() string {
+	return dl.Token.Literal
+}
+
+func (dl *DecimalLiteral) String() string {
+	return dl.Token.Literal
+}
+
 // StringLiteral represents a string literal
 type StringLiteral struct {
 	Token token.Token
@@ -95,6 +131,7 @@ type InfixExpression struct {
 	Operator string
 	Left     Expression
 	Right    Expression
+	Strict   bool // whether the expression is in a program with the strict pragma
 }
 
 func (ie *InfixExpression) expressionNode() {}
@@ -116,6 +153,48 @@ func (ie *InfixExpression) String() string {
 	return out.String()
 }
 
+// InterpolatedString represents a string with embedded expressions "x = ${x}",
+// its parts are string literals and the embedded expressions in order
+type InterpolatedString struct {
+	Token token.Token
+	Parts []Expression
+}
+
+func (is *InterpolatedString) expressionNode() {}
+
+// TokenLiteral implements the Node interface
+func (is *InterpolatedString) TokenLiteral() string {
+	return is.Token.Literal
+}
+
+func (is *InterpolatedString) String() string {
+	var out bytes.Buffer
+	for _, part := range is.Parts {
+		if str, ok := part.(*StringLiteral); ok {
+			out.WriteString(str.Value)
+		} else {
+			out.WriteString("${" + part.String() + "}")
+		}
+	}
+	return out.String()
+}
+
+// NullLiteral represents the null keyword
+type NullLiteral struct {
+	Token token.Token
+}
+
+func (nl *NullLiteral) expressionNode() {}
+
+// TokenLiteral implements the Node interface
+func (nl *NullLiteral) TokenLiteral() string {
+	return nl.Token.Literal
+}
+
+func (nl *NullLiteral) String() string {
+	return nl.Token.Literal
+}
+
 // Boolean represents a boolean expression.
 type Boolean struct {
 	Token token.Token
@@ -187,10 +266,13 @@ func (bs *BlockStatement) String() string {
 
 // FunctionLiteral represents a function literal.
 type FunctionLiteral struct {
-	Name   *Identifier
-	Token  token.Token
-	Params []*Identifier
-	Body   *BlockStatement
+	Name     *Identifier
+	Token    token.Token
+	Params   []Pattern
+	Defaults []Expression // the default value of each parameter, nil for parameters without one
+	Rest     *Identifier  // collects the arguments after the parameters into an array, if any
+	Body     *BlockStatement
+	Doc      string // the doc comment before the function, or before the let statement which binds it
 }
 
 func (fl *FunctionLiteral) expressionNode() {}
@@ -203,10 +285,7 @@ func (fl *FunctionLiteral) TokenLiteral() string {
 func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
 
-	params := []string{}
-	for _, p := range fl.Params {
-		params = append(params, p.String())
-	}
+	params := ParamStrings(fl.Params, fl.Defaults, fl.Rest)
 	out.WriteString(fl.TokenLiteral())
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
@@ -216,16 +295,33 @@ func (fl *FunctionLiteral) String() string {
 	return out.String()
 }
 
+// ParamStrings writes parameters the way they are declared, e.g. x, y = 2, ...rest
+func ParamStrings(params []Pattern, defaults []Expression, rest *Identifier) []string {
+	out := []string{}
+	for i, p := range params {
+		if i < len(defaults) && defaults[i] != nil {
+			out = append(out, p.String()+" = "+defaults[i].String())
+			continue
+		}
+		out = append(out, p.String())
+	}
+	if rest != nil {
+		out = append(out, "..."+rest.String())
+	}
+	return out
+}
+
 // CallExpression represents a call of a function foo(bar,foobar)
 type CallExpression struct {
 	Token    token.Token
 	Function Expression
 	Args     []Expression
+	Named    []*NamedArgument // arguments passed by the name of their parameter, after Args
 }
 
 func (ce *CallExpression) expressionNode() {}
 
-//TokenLiteral implements the Node interface
+// TokenLiteral implements the Node interface
 func (ce *CallExpression) TokenLiteral() string {
 	return ce.Token.Literal
 }
@@ -241,9 +337,48 @@ func (ce *CallExpression) String() string {
 	for i, arg := range ce.Args {
 		args[i] = arg.String()
 	}
+	for _, arg := range ce.Named {
+		args = append(args, arg.String())
+	}
 
 	out.WriteString(strings.Join(args, ", "))
 	out.WriteString(")")
 
 	return out.String()
 }
+
+// NamedArgument is an argument passed by the name of its parameter, e.g. x: 1
+type NamedArgument struct {
+	Token token.Token // the token.Ident token
+	Name  *Identifier
+	Value Expression
+}
+
+func (na *NamedArgument) expressionNode() {}
+
+// TokenLiteral implements the Node interface
+func (na *NamedArgument) TokenLiteral() string {
+	return na.Token.Literal
+}
+
+func (na *NamedArgument) String() string {
+	return na.Name.String() + ": " + na.Value.String()
+}
+
+// MemberExpression represents accessing a member of a value obj.member or obj?.member
+type MemberExpression struct {
+	Token  token.Token // the token.Dot or token.OptionalDot token
+	Object Expression
+	Member *Identifier
+}
+
+func (me *MemberExpression) expressionNode() {}
+
+// TokenLiteral implements the Node interface
+func (me *MemberExpression) TokenLiteral() string {
+	return me.Token.Literal
+}
+
+func (me *MemberExpression) String() string {
+	return me.Object.String() + me.Token.Literal + me.Member.String()
+}
@@ -6,11 +6,13 @@ import (
 	"github.com/latiif/lail/pkg/token"
 )
 
-// LetStatement defines the 'let <id> = <expr>';
+// LetStatement defines the 'let <id> = <expr>'; or 'let <pattern> = <expr>';
 type LetStatement struct {
-	Token token.Token // the token.Let token
-	Name  *Identifier
-	Value Expression
+	Token   token.Token // the token.Let token
+	Name    *Identifier
+	Pattern Pattern // destructures the value instead of binding it to Name, e.g. let [a, b] = xs;
+	Value   Expression
+	Doc     string // the doc comment before the statement, if any
 }
 
 func (ls *LetStatement) statementNode() {
@@ -26,7 +28,11 @@ func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(ls.TokenLiteral() + " ")
-	out.WriteString(ls.Name.String())
+	if ls.Pattern != nil {
+		out.WriteString(ls.Pattern.String())
+	} else {
+		out.WriteString(ls.Name.String())
+	}
 	out.WriteString(" = ")
 
 	if ls.Value != nil {
@@ -0,0 +1,75 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/latiif/lail/pkg/token"
+)
+
+// Pattern is what a value is bound to by a let statement or a function parameter,
+// either an identifier or a pattern which destructures the value
+type Pattern interface {
+	Expression
+	patternNode()
+}
+
+func (i *Identifier) patternNode() {}
+
+// ArrayPattern destructures an array, e.g. [a, b, ...rest]
+type ArrayPattern struct {
+	Token    token.Token // the token.Lbracket token
+	Elements []Pattern
+	Rest     *Identifier // binds the remaining elements, if any
+}
+
+func (ap *ArrayPattern) expressionNode() {}
+func (ap *ArrayPattern) patternNode()    {}
+
+// TokenLiteral implements the Node interface
+func (ap *ArrayPattern) TokenLiteral() string {
+	return ap.Token.Literal
+}
+
+func (ap *ArrayPattern) String() string {
+	var out bytes.Buffer
+	elements := []string{}
+	for _, e := range ap.Elements {
+		elements = append(elements, e.String())
+	}
+	if ap.Rest != nil {
+		elements = append(elements, "..."+ap.Rest.String())
+	}
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// HashPattern destructures a hash by binding its keys to identifiers of the same name, e.g. {name, age}
+type HashPattern struct {
+	Token token.Token // the token.Lbrace token
+	Keys  []*Identifier
+}
+
+func (hp *HashPattern) expressionNode() {}
+func (hp *HashPattern) patternNode()    {}
+
+// TokenLiteral implements the Node interface
+func (hp *HashPattern) TokenLiteral() string {
+	return hp.Token.Literal
+}
+
+func (hp *HashPattern) String() string {
+	var out bytes.Buffer
+	keys := []string{}
+	for _, k := range hp.Keys {
+		keys = append(keys, k.String())
+	}
+	out.WriteString("{")
+	out.WriteString(strings.Join(keys, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
@@ -3,6 +3,7 @@ package interpretor
 import (
 	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/latiif/lail/pkg/object"
 )
@@ -87,3 +88,31 @@ var builtins = map[string]*object.Builtin{
 		},
 	},
 }
+
+// registerBuiltins adds a group of builtins to the ones available to programs
+func registerBuiltins(group map[string]*object.Builtin) {
+	for name, builtin := range group {
+		builtins[name] = builtin
+	}
+}
+
+// modules are hashes of builtins, e.g. regex.match
+var modules = map[string]*object.Hash{}
+
+// typeModules maps types to the module whose members can be called on their values with dot notation
+var typeModules = map[object.ObjectType]*object.Hash{}
+
+// registerModule makes a group of builtins available to programs as members of a module
+func registerModule(name string, members map[string]*object.Builtin) *object.Hash {
+	module := object.NewHash()
+	names := make([]string, 0, len(members))
+	for member := range members {
+		names = append(names, member)
+	}
+	sort.Strings(names)
+	for _, member := range names {
+		module.Set(member, members[member])
+	}
+	modules[name] = module
+	return module
+}
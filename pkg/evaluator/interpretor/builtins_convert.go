@@ -0,0 +1,151 @@
+package interpretor
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+// defaultWidth is the width pretty fits values in if none is given
+const defaultWidth = 80
+
+func init() {
+	registerBuiltins(map[string]*object.Builtin{
+		// int(x, base) converts numbers and booleans to an integer, truncating towards zero,
+		// and parses strings of digits in base, which is 10 if none is given
+		"int": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newIllegalStateException(fmt.Sprintf("int takes 1 or 2 arguments; %d were provided.", len(args)))
+				}
+				if len(args) == 2 {
+					str, ok := args[0].(*object.String)
+					if !ok {
+						return newIllegalStateException(fmt.Sprintf("int: a base can only be given with a string; got %s", args[0].Type()))
+					}
+					base, ok := args[1].(*object.Integer)
+					if !ok || base.Big != nil || base.Value < 2 || base.Value > 36 {
+						return newIllegalStateException(fmt.Sprintf("int: base must be an integer from 2 to 36; got %s", args[1].Inspect()))
+					}
+					return parseInteger(str.Value, int(base.Value))
+				}
+				switch arg := args[0].(type) {
+				case *object.Integer:
+					return arg
+				case *object.Boolean:
+					return evalAsInteger(arg)
+				case *object.Float:
+					if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+						return newIllegalStateException(fmt.Sprintf("int: %s cannot be converted to an integer", arg.Inspect()))
+					}
+					res, _ := big.NewFloat(math.Trunc(arg.Value)).Int(nil)
+					return object.NewBigInteger(res)
+				case *object.Decimal:
+					return object.NewBigInteger(rescale(roundDecimal(arg, 0, Down), 0))
+				case *object.String:
+					return parseInteger(arg.Value, 10)
+				default:
+					return newIllegalStateException(fmt.Sprintf("int: %s cannot be converted to an integer", arg.Type()))
+				}
+			},
+		},
+		"float": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("float takes 1 argument; %d were provided.", len(args)))
+				}
+				switch arg := args[0].(type) {
+				case *object.Float:
+					return arg
+				case *object.Integer, *object.Boolean:
+					val, _ := evalAsFloat(arg)
+					return &object.Float{Value: val}
+				case *object.Decimal:
+					val, _ := strconv.ParseFloat(arg.Inspect(), 64)
+					return &object.Float{Value: val}
+				case *object.String:
+					val, err := strconv.ParseFloat(arg.Value, 64)
+					// ParseFloat also accepts "NaN", "Inf" and "infinity"
+					if err != nil || math.IsNaN(val) || math.IsInf(val, 0) {
+						return newIllegalStateException(fmt.Sprintf("float: %q is not a valid float", arg.Value))
+					}
+					return &object.Float{Value: val}
+				default:
+					return newIllegalStateException(fmt.Sprintf("float: %s cannot be converted to a float", arg.Type()))
+				}
+			},
+		},
+		"str": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("str takes 1 argument; %d were provided.", len(args)))
+				}
+				if str, ok := args[0].(*object.String); ok {
+					return str
+				}
+				return &object.String{Value: args[0].Inspect()}
+			},
+		},
+		// bool(x) is false for false, null and zero, and true for other booleans and numbers.
+		// Only the strings "true" and "false" can be converted.
+		"bool": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("bool takes 1 argument; %d were provided.", len(args)))
+				}
+				switch arg := args[0].(type) {
+				case *object.Boolean, *object.Integer, *object.Float, *object.Decimal:
+					return getBooleanObject(evalAsBoolean(arg))
+				case *object.Null:
+					return False
+				case *object.String:
+					val, ok := map[string]bool{"true": true, "false": false}[arg.Value]
+					if !ok {
+						return newIllegalStateException(fmt.Sprintf("bool: %q is not a valid boolean", arg.Value))
+					}
+					return getBooleanObject(val)
+				default:
+					return newIllegalStateException(fmt.Sprintf("bool: %s cannot be converted to a boolean", arg.Type()))
+				}
+			},
+		},
+		// repr(x) shows a value the way it is written in a program, e.g. strings are quoted
+		"repr": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("repr takes 1 argument; %d were provided.", len(args)))
+				}
+				return &object.String{Value: object.Repr(args[0])}
+			},
+		},
+		// pretty(x, width) is repr(x) split over indented lines so that it fits in width columns, 80 if none is given
+		"pretty": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newIllegalStateException(fmt.Sprintf("pretty takes 1 or 2 arguments; %d were provided.", len(args)))
+				}
+				width := int64(defaultWidth)
+				if len(args) == 2 {
+					integer, ok := args[1].(*object.Integer)
+					if !ok || integer.Big != nil || integer.Value < 1 {
+						return newIllegalStateException(fmt.Sprintf("pretty: width must be a positive integer; got %s", args[1].Inspect()))
+					}
+					width = integer.Value
+				}
+				return &object.String{Value: object.Pretty(args[0], int(width))}
+			},
+		},
+	})
+}
+
+// parseInteger parses an optionally signed string of digits in base
+func parseInteger(str string, base int) object.Object {
+	res, ok := new(big.Int).SetString(str, base)
+	if !ok {
+		return newIllegalStateException(fmt.Sprintf("int: %q is not a valid integer in base %d", str, base))
+	}
+	return object.NewBigInteger(res)
+}
@@ -0,0 +1,141 @@
+package interpretor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+var roundingModes = map[RoundingMode]bool{
+	HalfEven: true,
+	HalfUp:   true,
+	HalfDown: true,
+	Up:       true,
+	Down:     true,
+	Ceiling:  true,
+	Floor:    true,
+}
+
+func init() {
+	module := registerModule("decimal", map[string]*object.Builtin{
+		// of converts a string or an integer to a decimal
+		"of": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("decimal.of takes 1 argument; %d were provided.", len(args)))
+				}
+				switch arg := args[0].(type) {
+				case *object.Decimal:
+					return arg
+				case *object.Integer:
+					return decimalFromInteger(arg)
+				case *object.String:
+					res, ok := object.ParseDecimal(strings.TrimSpace(arg.Value))
+					if !ok {
+						return newIllegalStateException(fmt.Sprintf("decimal.of: %q is not a decimal.", arg.Value))
+					}
+					return res
+				default:
+					return newIllegalStateException(fmt.Sprintf("decimal.of: cannot convert %s of type %s.", arg.Inspect(), arg.Type()))
+				}
+			},
+		},
+		"toString": {
+			Function: func(args ...object.Object) object.Object {
+				d, err := decimalArgument("decimal.toString", args, 1)
+				if err != nil {
+					return err
+				}
+				return &object.String{Value: d.Inspect()}
+			},
+		},
+		// toInteger drops the decimal places
+		"toInteger": {
+			Function: func(args ...object.Object) object.Object {
+				d, err := decimalArgument("decimal.toInteger", args, 1)
+				if err != nil {
+					return err
+				}
+				truncated := roundDecimal(d, 0, Down)
+				return object.NewBigInteger(rescale(truncated, 0))
+			},
+		},
+		// round(d, places, mode) rounds to a number of decimal places, with the configured rounding mode if none is given
+		"round": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 2 && len(args) != 3 {
+					return newIllegalStateException(fmt.Sprintf("decimal.round takes 2 or 3 arguments; %d were provided.", len(args)))
+				}
+				d, err := decimalArgument("decimal.round", args[:1], 1)
+				if err != nil {
+					return err
+				}
+				places, err := decimalPlacesArgument("decimal.round", args[1])
+				if err != nil {
+					return err
+				}
+				mode := Settings.Decimal.Rounding
+				if len(args) == 3 {
+					mode = RoundingMode(args[2].Inspect())
+					if !roundingModes[mode] {
+						return newIllegalStateException(fmt.Sprintf("decimal.round: unknown rounding mode %s.", mode))
+					}
+				}
+				return roundDecimal(d, places, mode)
+			},
+		},
+		// setPrecision sets the number of decimal places kept when a result cannot be exact
+		"setPrecision": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("decimal.setPrecision takes 1 argument; %d were provided.", len(args)))
+				}
+				precision, err := decimalPlacesArgument("decimal.setPrecision", args[0])
+				if err != nil {
+					return err
+				}
+				Settings.Decimal.Precision = precision
+				return args[0]
+			},
+		},
+		// setRounding sets the rounding mode, one of halfEven, halfUp, halfDown, up, down, ceiling and floor
+		"setRounding": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("decimal.setRounding takes 1 argument; %d were provided.", len(args)))
+				}
+				mode := RoundingMode(args[0].Inspect())
+				if !roundingModes[mode] {
+					return newIllegalStateException(fmt.Sprintf("decimal.setRounding: unknown rounding mode %s.", mode))
+				}
+				Settings.Decimal.Rounding = mode
+				return args[0]
+			},
+		},
+	})
+	typeModules[object.DecimalObject] = module
+}
+
+func decimalArgument(name string, args []object.Object, count int) (*object.Decimal, object.Object) {
+	if len(args) != count {
+		return nil, newIllegalStateException(fmt.Sprintf("%s takes %d argument(s); %d were provided.", name, count, len(args)))
+	}
+	d, ok := args[0].(*object.Decimal)
+	if !ok {
+		return nil, newIllegalStateException(fmt.Sprintf("%s: %s is not a decimal.", name, args[0].Inspect()))
+	}
+	return d, nil
+}
+
+// maxDecimalPlaces bounds the number of decimal places, results with more digits take too long to compute
+const maxDecimalPlaces = 10000
+
+// decimalPlacesArgument validates a number of decimal places, from 0 to maxDecimalPlaces
+func decimalPlacesArgument(name string, arg object.Object) (int32, object.Object) {
+	places, ok := arg.(*object.Integer)
+	if !ok || places.Big != nil || places.Value < 0 || places.Value > maxDecimalPlaces {
+		return 0, newIllegalStateException(fmt.Sprintf("%s: %s is not a number of decimal places from 0 to %d", name, arg.Inspect(), maxDecimalPlaces))
+	}
+	return int32(places.Value), nil
+}
@@ -0,0 +1,132 @@
+//go:build !js
+// +build !js
+
+package interpretor
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+func init() {
+	registerBuiltins(map[string]*object.Builtin{
+		// exec(command, args, stdin, env, dir) runs command and returns its stdout, stderr and exit code.
+		// All but the command can be omitted or null, env is an array of "KEY=value" strings
+		// added to the current environment.
+		"exec": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) < 1 || len(args) > 5 {
+					return newIllegalStateException(fmt.Sprintf("exec takes 1 to 5 arguments; %d were provided.", len(args)))
+				}
+				if !Settings.Sandbox.AllowExec {
+					return newIllegalStateException("exec: running programs is not permitted")
+				}
+				for len(args) < 5 {
+					args = append(args, Null)
+				}
+				command, ok := args[0].(*object.String)
+				if !ok {
+					return newIllegalStateException(fmt.Sprintf("exec: %s is not a string.", args[0].Inspect()))
+				}
+				commandArgs, err := stringsArgument("exec", args[1])
+				if err != nil {
+					return err
+				}
+				stdin, err := optionalStringArgument("exec: stdin", args[2])
+				if err != nil {
+					return err
+				}
+				env, err := envArgument(args[3])
+				if err != nil {
+					return err
+				}
+				dir, err := optionalStringArgument("exec: dir", args[4])
+				if err != nil {
+					return err
+				}
+
+				cmd := exec.Command(command.Value, commandArgs...)
+				var stdout, stderr bytes.Buffer
+				cmd.Stdout = &stdout
+				cmd.Stderr = &stderr
+				if stdin != nil {
+					cmd.Stdin = strings.NewReader(stdin.Value)
+				}
+				if len(env) != 0 {
+					cmd.Env = append(os.Environ(), env...)
+				}
+				if dir != nil {
+					cmd.Dir = dir.Value
+				}
+
+				code := 0
+				if runErr := cmd.Run(); runErr != nil {
+					exitErr, ok := runErr.(*exec.ExitError)
+					if !ok {
+						return newIllegalStateException(fmt.Sprintf("exec: %v", runErr))
+					}
+					code = exitErr.ExitCode()
+				}
+
+				res := object.NewHash()
+				res.Set("stdout", &object.String{Value: stdout.String()})
+				res.Set("stderr", &object.String{Value: stderr.String()})
+				res.Set("code", &object.Integer{Value: int64(code)})
+				return res
+			},
+		},
+	})
+}
+
+// stringsArgument converts an array argument to a slice of strings, null is an empty slice
+func stringsArgument(name string, arg object.Object) ([]string, object.Object) {
+	if arg == Null {
+		return []string{}, nil
+	}
+	array, ok := arg.(*object.Array)
+	if !ok {
+		return nil, newIllegalStateException(fmt.Sprintf("%s: %s is not an array literal.", name, arg.Inspect()))
+	}
+	res := make([]string, len(array.Value))
+	for i, element := range array.Value {
+		res[i] = element.Inspect()
+	}
+	return res, nil
+}
+
+// optionalStringArgument checks that arg is a string or null, which is returned as nil
+func optionalStringArgument(name string, arg object.Object) (*object.String, object.Object) {
+	if arg == Null {
+		return nil, nil
+	}
+	str, ok := arg.(*object.String)
+	if !ok {
+		return nil, newIllegalStateException(fmt.Sprintf("%s must be a string; got %s", name, arg.Type()))
+	}
+	return str, nil
+}
+
+// envArgument converts the env argument of exec, an array of "KEY=value" strings, null is an empty slice
+func envArgument(arg object.Object) ([]string, object.Object) {
+	if arg == Null {
+		return []string{}, nil
+	}
+	array, ok := arg.(*object.Array)
+	if !ok {
+		return nil, newIllegalStateException(fmt.Sprintf("exec: env must be an array of strings; got %s", arg.Type()))
+	}
+	res := make([]string, len(array.Value))
+	for i, element := range array.Value {
+		str, ok := element.(*object.String)
+		if !ok {
+			return nil, newIllegalStateException(fmt.Sprintf("exec: env must contain only strings; got %s", element.Inspect()))
+		}
+		res[i] = str.Value
+	}
+	return res, nil
+}
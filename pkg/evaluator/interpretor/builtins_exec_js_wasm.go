@@ -0,0 +1,13 @@
+package interpretor
+
+import "github.com/latiif/lail/pkg/object"
+
+func init() {
+	registerBuiltins(map[string]*object.Builtin{
+		"exec": {
+			Function: func(args ...object.Object) object.Object {
+				return newIllegalStateException("exec: running programs is not supported")
+			},
+		},
+	})
+}
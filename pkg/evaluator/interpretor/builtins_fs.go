@@ -0,0 +1,192 @@
+package interpretor
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+func init() {
+	registerBuiltins(map[string]*object.Builtin{
+		"readFile": {
+			Function: func(args ...object.Object) object.Object {
+				path, err := pathArgument("readFile", args, 1, false)
+				if err != nil {
+					return err
+				}
+				contents, readErr := ioutil.ReadFile(path)
+				if readErr != nil {
+					return newIllegalStateException(fmt.Sprintf("readFile: %v", readErr))
+				}
+				return &object.String{Value: string(contents)}
+			},
+		},
+		"writeFile": {
+			Function: func(args ...object.Object) object.Object {
+				return writeToFile("writeFile", args, os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+			},
+		},
+		"appendFile": {
+			Function: func(args ...object.Object) object.Object {
+				return writeToFile("appendFile", args, os.O_CREATE|os.O_WRONLY|os.O_APPEND)
+			},
+		},
+		"exists": {
+			Function: func(args ...object.Object) object.Object {
+				path, err := pathArgument("exists", args, 1, false)
+				if err != nil {
+					return err
+				}
+				_, statErr := os.Stat(path)
+				return getBooleanObject(statErr == nil)
+			},
+		},
+		"listDir": {
+			Function: func(args ...object.Object) object.Object {
+				path, err := pathArgument("listDir", args, 1, false)
+				if err != nil {
+					return err
+				}
+				entries, readErr := ioutil.ReadDir(path)
+				if readErr != nil {
+					return newIllegalStateException(fmt.Sprintf("listDir: %v", readErr))
+				}
+				names := make([]string, len(entries))
+				for i, entry := range entries {
+					names[i] = entry.Name()
+				}
+				sort.Strings(names)
+				return newStringArray(names)
+			},
+		},
+		"mkdir": {
+			Function: func(args ...object.Object) object.Object {
+				path, err := pathArgument("mkdir", args, 1, true)
+				if err != nil {
+					return err
+				}
+				if mkdirErr := os.MkdirAll(path, 0755); mkdirErr != nil {
+					return newIllegalStateException(fmt.Sprintf("mkdir: %v", mkdirErr))
+				}
+				return True
+			},
+		},
+		"remove": {
+			Function: func(args ...object.Object) object.Object {
+				path, err := pathArgument("remove", args, 1, true)
+				if err != nil {
+					return err
+				}
+				if removeErr := os.Remove(path); removeErr != nil {
+					return newIllegalStateException(fmt.Sprintf("remove: %v", removeErr))
+				}
+				return True
+			},
+		},
+		"open": {
+			Function: func(args ...object.Object) object.Object {
+				path, err := pathArgument("open", args, 1, false)
+				if err != nil {
+					return err
+				}
+				handle, openErr := os.Open(path)
+				if openErr != nil {
+					return newIllegalStateException(fmt.Sprintf("open: %v", openErr))
+				}
+				return object.NewFile(path, handle)
+			},
+		},
+		"close": {
+			Function: func(args ...object.Object) object.Object {
+				file, err := fileArgument("close", args)
+				if err != nil {
+					return err
+				}
+				file.Closed = true
+				if closeErr := file.Handle.Close(); closeErr != nil {
+					return newIllegalStateException(fmt.Sprintf("close: %v", closeErr))
+				}
+				return True
+			},
+		},
+	})
+}
+
+// writeToFile implements writeFile and appendFile, flag decides whether the file is truncated or appended to
+func writeToFile(name string, args []object.Object, flag int) object.Object {
+	path, err := pathArgument(name, args, 2, true)
+	if err != nil {
+		return err
+	}
+	handle, openErr := os.OpenFile(path, flag, 0644)
+	if openErr != nil {
+		return newIllegalStateException(fmt.Sprintf("%s: %v", name, openErr))
+	}
+	defer handle.Close()
+	if _, writeErr := io.WriteString(handle, args[1].Inspect()); writeErr != nil {
+		return newIllegalStateException(fmt.Sprintf("%s: %v", name, writeErr))
+	}
+	return True
+}
+
+// pathArgument validates the arguments of a file system builtin whose first argument is a path
+// and checks the path against the sandbox settings
+func pathArgument(name string, args []object.Object, count int, write bool) (string, object.Object) {
+	if len(args) != count {
+		return "", newIllegalStateException(fmt.Sprintf("%s takes %d argument(s); %d were provided.", name, count, len(args)))
+	}
+	path, ok := args[0].(*object.String)
+	if !ok {
+		return "", newIllegalStateException(fmt.Sprintf("%s: %s is not a string.", name, args[0].Inspect()))
+	}
+	if err := Settings.Sandbox.checkPath(path.Value, write); err != "" {
+		return "", newIllegalStateException(fmt.Sprintf("%s: %s", name, err))
+	}
+	return path.Value, nil
+}
+
+func fileArgument(name string, args []object.Object) (*object.File, object.Object) {
+	if len(args) != 1 {
+		return nil, newIllegalStateException(fmt.Sprintf("%s takes 1 argument; %d were provided.", name, len(args)))
+	}
+	file, ok := args[0].(*object.File)
+	if !ok {
+		return nil, newIllegalStateException(fmt.Sprintf("%s: %s is not a file.", name, args[0].Inspect()))
+	}
+	if file.Closed {
+		return nil, newIllegalStateException(fmt.Sprintf("%s: %s is already closed", name, file.Path))
+	}
+	return file, nil
+}
+
+// checkPath returns a description of why the sandbox denies access to path, or "" if it is allowed
+func (s Sandbox) checkPath(path string, write bool) string {
+	if write && !s.AllowWrite {
+		return "writing files is not permitted"
+	}
+	if !write && !s.AllowRead {
+		return "reading files is not permitted"
+	}
+	if s.Root == "" {
+		return ""
+	}
+	root, err := filepath.Abs(s.Root)
+	if err != nil {
+		return err.Error()
+	}
+	target, err := filepath.Abs(path)
+	if err != nil {
+		return err.Error()
+	}
+	rel, err := filepath.Rel(root, target)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Sprintf("%s is outside of %s", path, s.Root)
+	}
+	return ""
+}
@@ -0,0 +1,109 @@
+package interpretor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+// stdin buffers Settings.Stdin, so that it is not consumed beyond what is read by the builtins
+var stdin struct {
+	source io.Reader
+	reader *bufio.Reader
+}
+
+func stdinReader() *bufio.Reader {
+	if stdin.source != Settings.Stdin {
+		stdin.source = Settings.Stdin
+		stdin.reader = bufio.NewReader(Settings.Stdin)
+	}
+	return stdin.reader
+}
+
+func init() {
+	registerBuiltins(map[string]*object.Builtin{
+		"readLine": {
+			Function: func(args ...object.Object) object.Object {
+				reader, err := readerArgument("readLine", args)
+				if err != nil {
+					return err
+				}
+				line, ok, readErr := readLine(reader)
+				if readErr != nil {
+					return newIllegalStateException(fmt.Sprintf("readLine: %v", readErr))
+				}
+				// nothing left to read
+				if !ok {
+					return Null
+				}
+				return &object.String{Value: line}
+			},
+		},
+		"readAll": {
+			Function: func(args ...object.Object) object.Object {
+				reader, err := readerArgument("readAll", args)
+				if err != nil {
+					return err
+				}
+				contents, readErr := ioutil.ReadAll(reader)
+				if readErr != nil {
+					return newIllegalStateException(fmt.Sprintf("readAll: %v", readErr))
+				}
+				return &object.String{Value: string(contents)}
+			},
+		},
+		// lines returns an iterator, a function which reads the next line on each call and returns null at the end,
+		// so that a pipeline is processed as it streams in rather than once all of it is read
+		"lines": {
+			Function: func(args ...object.Object) object.Object {
+				reader, err := readerArgument("lines", args)
+				if err != nil {
+					return err
+				}
+				return &object.Builtin{
+					Function: func(args ...object.Object) object.Object {
+						if len(args) != 0 {
+							return newIllegalStateException(fmt.Sprintf("the iterator returned by lines takes no arguments; %d were provided", len(args)))
+						}
+						line, ok, readErr := readLine(reader)
+						if readErr != nil {
+							return newIllegalStateException(fmt.Sprintf("lines: %v", readErr))
+						}
+						if !ok {
+							return Null
+						}
+						return &object.String{Value: line}
+					},
+				}
+			},
+		},
+	})
+}
+
+// readerArgument returns the reader of the file given as argument, or standard input when no argument is given
+func readerArgument(name string, args []object.Object) (*bufio.Reader, object.Object) {
+	if len(args) == 0 {
+		return stdinReader(), nil
+	}
+	file, err := fileArgument(name, args)
+	if err != nil {
+		return nil, err
+	}
+	return file.Reader, nil
+}
+
+// readLine reads the next line without its line terminator, ok is false if there is nothing left to read
+func readLine(reader *bufio.Reader) (line string, ok bool, err error) {
+	line, err = reader.ReadString('\n')
+	if err == io.EOF {
+		return strings.TrimRight(line, "\r\n"), line != "", nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return strings.TrimRight(line, "\r\n"), true, nil
+}
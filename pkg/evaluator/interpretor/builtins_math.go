@@ -0,0 +1,202 @@
+package interpretor
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+func init() {
+	module := registerModule("math", map[string]*object.Builtin{
+		"abs": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("math.abs takes 1 argument; %d were provided.", len(args)))
+				}
+				switch arg := args[0].(type) {
+				case *object.Integer:
+					if compareIntegers(arg, &object.Integer{Value: 0}) < 0 {
+						return negateInteger(arg)
+					}
+					return arg
+				case *object.Float:
+					return &object.Float{Value: math.Abs(arg.Value)}
+				default:
+					return newIllegalStateException(fmt.Sprintf("math.abs: %s is not a number.", arg.Inspect()))
+				}
+			},
+		},
+		"min":   extremum("min", -1),
+		"max":   extremum("max", 1),
+		"floor": rounding("floor", math.Floor),
+		"ceil":  rounding("ceil", math.Ceil),
+		"round": rounding("round", math.Round),
+		"sqrt":  floatFunction("sqrt", math.Sqrt),
+		"exp":   floatFunction("exp", math.Exp),
+		"sin":   floatFunction("sin", math.Sin),
+		"cos":   floatFunction("cos", math.Cos),
+		"tan":   floatFunction("tan", math.Tan),
+		"asin":  floatFunction("asin", math.Asin),
+		"acos":  floatFunction("acos", math.Acos),
+		"atan":  floatFunction("atan", math.Atan),
+		// log(x) is the natural logarithm, log(x, base) the logarithm in base
+		"log": {
+			Function: func(args ...object.Object) object.Object {
+				values, err := numberArguments("math.log", args, 1, 2)
+				if err != nil {
+					return err
+				}
+				if len(values) == 2 {
+					return &object.Float{Value: math.Log(values[0]) / math.Log(values[1])}
+				}
+				return &object.Float{Value: math.Log(values[0])}
+			},
+		},
+		"atan2": {
+			Function: func(args ...object.Object) object.Object {
+				values, err := numberArguments("math.atan2", args, 2, 2)
+				if err != nil {
+					return err
+				}
+				return &object.Float{Value: math.Atan2(values[0], values[1])}
+			},
+		},
+		// pow of integers with a non-negative exponent is an integer
+		"pow": {
+			Function: func(args ...object.Object) object.Object {
+				values, err := numberArguments("math.pow", args, 2, 2)
+				if err != nil {
+					return err
+				}
+				base, baseIsInt := args[0].(*object.Integer)
+				exp, expIsInt := args[1].(*object.Integer)
+				if baseIsInt && expIsInt && exp.BigValue().Sign() >= 0 {
+					return powerIntegers(base, exp)
+				}
+				return &object.Float{Value: math.Pow(values[0], values[1])}
+			},
+		},
+		"gcd": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newIllegalStateException(fmt.Sprintf("math.gcd takes 2 arguments; %d were provided.", len(args)))
+				}
+				a, aOk := args[0].(*object.Integer)
+				b, bOk := args[1].(*object.Integer)
+				if !aOk || !bOk {
+					return newIllegalStateException(fmt.Sprintf("math.gcd: %s and %s are not integers.", args[0].Inspect(), args[1].Inspect()))
+				}
+				x := new(big.Int).Abs(a.BigValue())
+				y := new(big.Int).Abs(b.BigValue())
+				return object.NewBigInteger(new(big.Int).GCD(nil, nil, x, y))
+			},
+		},
+		// clamp(x, low, high) limits x to [low, high]
+		"clamp": {
+			Function: func(args ...object.Object) object.Object {
+				values, err := numberArguments("math.clamp", args, 3, 3)
+				if err != nil {
+					return err
+				}
+				if values[1] > values[2] {
+					return newIllegalStateException(fmt.Sprintf("math.clamp: empty range [%s, %s]", args[1].Inspect(), args[2].Inspect()))
+				}
+				switch {
+				case values[0] < values[1]:
+					return args[1]
+				case values[0] > values[2]:
+					return args[2]
+				default:
+					return args[0]
+				}
+			},
+		},
+	})
+	module.Set("pi", &object.Float{Value: math.Pi})
+	module.Set("e", &object.Float{Value: math.E})
+	typeModules[object.IntegerObject] = module
+	typeModules[object.FloatObject] = module
+}
+
+// extremum creates min or max, which take numbers or an array of numbers.
+// sign is the result of compareFloats for which the candidate replaces the current extremum.
+func extremum(name string, sign int) *object.Builtin {
+	return &object.Builtin{
+		Function: func(args ...object.Object) object.Object {
+			if len(args) == 1 {
+				if array, ok := args[0].(*object.Array); ok {
+					args = array.Value
+				}
+			}
+			if len(args) == 0 {
+				return newIllegalStateException(fmt.Sprintf("math.%s takes at least 1 number; none were provided.", name))
+			}
+			values, err := numberArguments("math."+name, args, 1, len(args))
+			if err != nil {
+				return err
+			}
+			res := 0
+			for i := range values {
+				if compareFloats(values[i], values[res]) == sign {
+					res = i
+				}
+			}
+			return args[res]
+		},
+	}
+}
+
+// rounding creates a builtin rounding floats to integers
+func rounding(name string, round func(float64) float64) *object.Builtin {
+	return &object.Builtin{
+		Function: func(args ...object.Object) object.Object {
+			values, err := numberArguments("math."+name, args, 1, 1)
+			if err != nil {
+				return err
+			}
+			// integers are already round
+			if integer, ok := args[0].(*object.Integer); ok {
+				return integer
+			}
+			if math.IsNaN(values[0]) || math.IsInf(values[0], 0) {
+				return newIllegalStateException(fmt.Sprintf("math.%s: %s cannot be rounded to an integer", name, args[0].Inspect()))
+			}
+			rounded, _ := big.NewFloat(round(values[0])).Int(nil)
+			return object.NewBigInteger(rounded)
+		},
+	}
+}
+
+// floatFunction creates a builtin applying f to a number
+func floatFunction(name string, f func(float64) float64) *object.Builtin {
+	return &object.Builtin{
+		Function: func(args ...object.Object) object.Object {
+			values, err := numberArguments("math."+name, args, 1, 1)
+			if err != nil {
+				return err
+			}
+			return &object.Float{Value: f(values[0])}
+		},
+	}
+}
+
+// numberArguments validates that between min and max numbers were given and converts them to float64
+func numberArguments(name string, args []object.Object, min, max int) ([]float64, object.Object) {
+	if len(args) < min || len(args) > max {
+		if min == max {
+			return nil, newIllegalStateException(fmt.Sprintf("%s takes %d argument(s); %d were provided.", name, min, len(args)))
+		}
+		return nil, newIllegalStateException(fmt.Sprintf("%s takes %d to %d arguments; %d were provided.", name, min, max, len(args)))
+	}
+	values := make([]float64, len(args))
+	for i, arg := range args {
+		val, ok := numberArgument(arg)
+		if !ok {
+			return nil, newIllegalStateException(fmt.Sprintf("%s: %s is not a number.", name, arg.Inspect()))
+		}
+		values[i] = val
+	}
+	return values, nil
+}
@@ -0,0 +1,61 @@
+package interpretor
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+func init() {
+	registerBuiltins(map[string]*object.Builtin{
+		"args": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 0 {
+					return newIllegalStateException(fmt.Sprintf("args takes no arguments; %d were provided.", len(args)))
+				}
+				return newStringArray(Settings.Args)
+			},
+		},
+		"env": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("env takes 1 argument; %d were provided.", len(args)))
+				}
+				// unset variables are null rather than ""
+				if val, ok := os.LookupEnv(args[0].Inspect()); ok {
+					return &object.String{Value: val}
+				}
+				return Null
+			},
+		},
+		"setenv": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newIllegalStateException(fmt.Sprintf("setenv takes 2 arguments; %d were provided.", len(args)))
+				}
+				if err := os.Setenv(args[0].Inspect(), args[1].Inspect()); err != nil {
+					return newIllegalStateException(fmt.Sprintf("setenv: %v", err))
+				}
+				return args[1]
+			},
+		},
+		"exit": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) > 1 {
+					return newIllegalStateException(fmt.Sprintf("exit takes at most 1 argument; %d were provided.", len(args)))
+				}
+				code := 0
+				if len(args) == 1 {
+					integer, err := int64Argument("exit", args[0])
+					if err != nil {
+						return err
+					}
+					code = int(integer)
+				}
+				Settings.Exit(code)
+				return Null
+			},
+		},
+	})
+}
@@ -0,0 +1,132 @@
+package interpretor
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+func init() {
+	registerModule("random", map[string]*object.Builtin{
+		// int(min, max) returns an integer in [min, max]
+		"int": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 2 {
+					return newIllegalStateException(fmt.Sprintf("random.int takes 2 arguments; %d were provided.", len(args)))
+				}
+				min, minOk := args[0].(*object.Integer)
+				max, maxOk := args[1].(*object.Integer)
+				if !minOk || !maxOk {
+					return newIllegalStateException(fmt.Sprintf("random.int: %s and %s are not integers.", args[0].Inspect(), args[1].Inspect()))
+				}
+				if compareIntegers(min, max) > 0 {
+					return newIllegalStateException(fmt.Sprintf("random.int: empty range [%s, %s]", min.Inspect(), max.Inspect()))
+				}
+				if span := max.Value - min.Value; min.Big == nil && max.Big == nil && span >= 0 && span < math.MaxInt64 {
+					return &object.Integer{Value: min.Value + Settings.Random.Int63n(span+1)}
+				}
+				// the range has more integers than Int63n can pick from, e.g. [0, 9223372036854775807]
+				span := new(big.Int).Sub(max.BigValue(), min.BigValue())
+				n := new(big.Int).Rand(Settings.Random, span.Add(span, big.NewInt(1)))
+				return object.NewBigInteger(n.Add(n, min.BigValue()))
+			},
+		},
+		// float() returns a float in [0, 1), float(min, max) a float in [min, max)
+		"float": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 0 && len(args) != 2 {
+					return newIllegalStateException(fmt.Sprintf("random.float takes 0 or 2 arguments; %d were provided.", len(args)))
+				}
+				val := Settings.Random.Float64()
+				if len(args) == 0 {
+					return &object.Float{Value: val}
+				}
+				min, minOk := numberArgument(args[0])
+				max, maxOk := numberArgument(args[1])
+				if !minOk || !maxOk {
+					return newIllegalStateException(fmt.Sprintf("random.float: %s and %s are not numbers.", args[0].Inspect(), args[1].Inspect()))
+				}
+				return &object.Float{Value: min + val*(max-min)}
+			},
+		},
+		// choice returns a random element, null if there are none
+		"choice": {
+			Function: func(args ...object.Object) object.Object {
+				array, err := arrayArgument("random.choice", args, 1)
+				if err != nil {
+					return err
+				}
+				if len(array.Value) == 0 {
+					return Null
+				}
+				return array.Value[Settings.Random.Intn(len(array.Value))]
+			},
+		},
+		// shuffle returns a shuffled copy of an array
+		"shuffle": {
+			Function: func(args ...object.Object) object.Object {
+				array, err := arrayArgument("random.shuffle", args, 1)
+				if err != nil {
+					return err
+				}
+				res := &object.Array{Value: make([]object.Object, len(array.Value))}
+				copy(res.Value, array.Value)
+				Settings.Random.Shuffle(len(res.Value), func(i, j int) {
+					res.Value[i], res.Value[j] = res.Value[j], res.Value[i]
+				})
+				return res
+			},
+		},
+		// sample(array, k) returns k elements picked at random without replacement
+		"sample": {
+			Function: func(args ...object.Object) object.Object {
+				array, err := arrayArgument("random.sample", args, 2)
+				if err != nil {
+					return err
+				}
+				k, err := int64Argument("random.sample", args[1])
+				if err != nil {
+					return err
+				}
+				if k < 0 || k > int64(len(array.Value)) {
+					return newIllegalStateException(fmt.Sprintf("random.sample: cannot pick %d out of %d elements", k, len(array.Value)))
+				}
+				res := &object.Array{Value: make([]object.Object, k)}
+				for i, j := range Settings.Random.Perm(len(array.Value))[:k] {
+					res.Value[i] = array.Value[j]
+				}
+				return res
+			},
+		},
+	})
+}
+
+// arrayArgument validates the arguments of a builtin whose first argument is an array
+func arrayArgument(name string, args []object.Object, count int) (*object.Array, object.Object) {
+	if len(args) != count {
+		return nil, newIllegalStateException(fmt.Sprintf("%s takes %d argument(s); %d were provided.", name, count, len(args)))
+	}
+	array, ok := args[0].(*object.Array)
+	if !ok {
+		return nil, newIllegalStateException(fmt.Sprintf("%s: %s is not an array literal.", name, args[0].Inspect()))
+	}
+	return array, nil
+}
+
+// numberArgument converts an integer or a float to a float64
+func numberArgument(arg object.Object) (float64, bool) {
+	switch arg := arg.(type) {
+	case *object.Integer:
+		if arg.Big != nil {
+			val, _ := new(big.Float).SetInt(arg.Big).Float64()
+			return val, true
+		}
+		return float64(arg.Value), true
+	case *object.Float:
+		return arg.Value, true
+	default:
+		return 0, false
+	}
+}
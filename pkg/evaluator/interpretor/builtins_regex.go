@@ -0,0 +1,158 @@
+package interpretor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+func init() {
+	module := registerModule("regex", map[string]*object.Builtin{
+		"compile": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("regex.compile takes 1 argument; %d were provided.", len(args)))
+				}
+				re, err := regexArgument("regex.compile", args[0])
+				if err != nil {
+					return err
+				}
+				return re
+			},
+		},
+		"match": {
+			Function: func(args ...object.Object) object.Object {
+				re, str, err := regexArguments("regex.match", args, 2)
+				if err != nil {
+					return err
+				}
+				return getBooleanObject(re.Value.MatchString(str))
+			},
+		},
+		"find": {
+			Function: func(args ...object.Object) object.Object {
+				re, str, err := regexArguments("regex.find", args, 2)
+				if err != nil {
+					return err
+				}
+				loc := re.Value.FindStringIndex(str)
+				// no match
+				if loc == nil {
+					return Null
+				}
+				return &object.String{Value: str[loc[0]:loc[1]]}
+			},
+		},
+		"findAll": {
+			Function: func(args ...object.Object) object.Object {
+				re, str, err := regexArguments("regex.findAll", args, 2)
+				if err != nil {
+					return err
+				}
+				return newStringArray(re.Value.FindAllString(str, -1))
+			},
+		},
+		// groups returns the groups of the first match as a hash, named groups are keyed by their name
+		// and unnamed groups by their index
+		"groups": {
+			Function: func(args ...object.Object) object.Object {
+				re, str, err := regexArguments("regex.groups", args, 2)
+				if err != nil {
+					return err
+				}
+				match := re.Value.FindStringSubmatch(str)
+				// no match
+				if match == nil {
+					return Null
+				}
+				res := object.NewHash()
+				for i, name := range re.Value.SubexpNames() {
+					if name == "" {
+						name = strconv.Itoa(i)
+					}
+					res.Set(name, &object.String{Value: match[i]})
+				}
+				return res
+			},
+		},
+		// replace replaces all matches, the replacement is either a string in which $1 or ${name} expand
+		// to groups, or a function called with each match which returns its replacement
+		"replace": {
+			Function: func(args ...object.Object) object.Object {
+				re, str, err := regexArguments("regex.replace", args, 3)
+				if err != nil {
+					return err
+				}
+				switch replacement := args[2].(type) {
+				case *object.Function, *object.Builtin:
+					var callbackErr object.Object
+					res := re.Value.ReplaceAllStringFunc(str, func(match string) string {
+						val := applyFunction(replacement, []object.Object{&object.String{Value: match}})
+						if val.Type() == object.ErrorObject {
+							callbackErr = val
+						}
+						return val.Inspect()
+					})
+					if callbackErr != nil {
+						return callbackErr
+					}
+					return &object.String{Value: res}
+				default:
+					return &object.String{Value: re.Value.ReplaceAllString(str, replacement.Inspect())}
+				}
+			},
+		},
+		"split": {
+			Function: func(args ...object.Object) object.Object {
+				re, str, err := regexArguments("regex.split", args, 2)
+				if err != nil {
+					return err
+				}
+				return newStringArray(re.Value.Split(str, -1))
+			},
+		},
+	})
+	typeModules[object.RegexObject] = module
+}
+
+// regexArguments validates the arguments of a regex builtin taking a pattern and a string,
+// the pattern is either a compiled regex or a string
+func regexArguments(name string, args []object.Object, count int) (*object.Regex, string, object.Object) {
+	if len(args) != count {
+		return nil, "", newIllegalStateException(fmt.Sprintf("%s takes %d arguments; %d were provided.", name, count, len(args)))
+	}
+	re, err := regexArgument(name, args[0])
+	if err != nil {
+		return nil, "", err
+	}
+	str, ok := args[1].(*object.String)
+	if !ok {
+		return nil, "", newIllegalStateException(fmt.Sprintf("%s: %s is not a string.", name, args[1].Inspect()))
+	}
+	return re, str.Value, nil
+}
+
+func regexArgument(name string, arg object.Object) (*object.Regex, object.Object) {
+	switch arg := arg.(type) {
+	case *object.Regex:
+		return arg, nil
+	case *object.String:
+		re, err := regexp.Compile(arg.Value)
+		if err != nil {
+			return nil, newIllegalStateException(fmt.Sprintf("%s: %v", name, err))
+		}
+		return &object.Regex{Value: re}, nil
+	default:
+		return nil, newIllegalStateException(fmt.Sprintf("%s: %s is not a regex.", name, arg.Inspect()))
+	}
+}
+
+func newStringArray(values []string) *object.Array {
+	res := &object.Array{Value: make([]object.Object, len(values))}
+	for i, val := range values {
+		res.Value[i] = &object.String{Value: val}
+	}
+	return res
+}
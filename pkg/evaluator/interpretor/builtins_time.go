@@ -0,0 +1,167 @@
+package interpretor
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+func init() {
+	module := registerModule("time", map[string]*object.Builtin{
+		"now": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 0 {
+					return newIllegalStateException(fmt.Sprintf("time.now takes no arguments; %d were provided.", len(args)))
+				}
+				return &object.Time{Value: Settings.Clock.Now()}
+			},
+		},
+		"fromUnix": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("time.fromUnix takes 1 argument; %d were provided.", len(args)))
+				}
+				seconds, err := int64Argument("time.fromUnix", args[0])
+				if err != nil {
+					return err
+				}
+				return &object.Time{Value: time.Unix(seconds, 0).UTC()}
+			},
+		},
+		// parse(str, layout) parses str according to a Go layout, RFC 3339 if none is given
+		"parse": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newIllegalStateException(fmt.Sprintf("time.parse takes 1 or 2 arguments; %d were provided.", len(args)))
+				}
+				layout := time.RFC3339
+				if len(args) == 2 {
+					layout = args[1].Inspect()
+				}
+				t, err := time.Parse(layout, args[0].Inspect())
+				if err != nil {
+					return newIllegalStateException(fmt.Sprintf("time.parse: %v", err))
+				}
+				return &object.Time{Value: t}
+			},
+		},
+		// format(t, layout) formats t according to a Go layout, RFC 3339 if none is given
+		"format": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 && len(args) != 2 {
+					return newIllegalStateException(fmt.Sprintf("time.format takes 1 or 2 arguments; %d were provided.", len(args)))
+				}
+				t, err := timeArgument("time.format", args[0])
+				if err != nil {
+					return err
+				}
+				layout := time.RFC3339
+				if len(args) == 2 {
+					layout = args[1].Inspect()
+				}
+				return &object.String{Value: t.Format(layout)}
+			},
+		},
+		// duration parses durations such as "1h30m" or "250ms"
+		"duration": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("time.duration takes 1 argument; %d were provided.", len(args)))
+				}
+				d, err := time.ParseDuration(args[0].Inspect())
+				if err != nil {
+					return newIllegalStateException(fmt.Sprintf("time.duration: %v", err))
+				}
+				return &object.Duration{Value: d}
+			},
+		},
+		"since": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("time.since takes 1 argument; %d were provided.", len(args)))
+				}
+				t, err := timeArgument("time.since", args[0])
+				if err != nil {
+					return err
+				}
+				return &object.Duration{Value: Settings.Clock.Now().Sub(t)}
+			},
+		},
+		// sleep waits for a duration, or a number of milliseconds
+		"sleep": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) != 1 {
+					return newIllegalStateException(fmt.Sprintf("time.sleep takes 1 argument; %d were provided.", len(args)))
+				}
+				switch d := args[0].(type) {
+				case *object.Duration:
+					Settings.Clock.Sleep(d.Value)
+				case *object.Integer:
+					if d.Big != nil || d.Value < 0 || d.Value > int64(math.MaxInt64/time.Millisecond) {
+						return newIllegalStateException(fmt.Sprintf("time.sleep: %s milliseconds is out of range", d.Inspect()))
+					}
+					Settings.Clock.Sleep(time.Duration(d.Value) * time.Millisecond)
+				default:
+					return newIllegalStateException(fmt.Sprintf("time.sleep: %s is not a duration.", args[0].Inspect()))
+				}
+				return Null
+			},
+		},
+		"unix":    timeAccessor("unix", func(t time.Time) int64 { return t.Unix() }),
+		"year":    timeAccessor("year", func(t time.Time) int64 { return int64(t.Year()) }),
+		"month":   timeAccessor("month", func(t time.Time) int64 { return int64(t.Month()) }),
+		"day":     timeAccessor("day", func(t time.Time) int64 { return int64(t.Day()) }),
+		"hour":    timeAccessor("hour", func(t time.Time) int64 { return int64(t.Hour()) }),
+		"minute":  timeAccessor("minute", func(t time.Time) int64 { return int64(t.Minute()) }),
+		"second":  timeAccessor("second", func(t time.Time) int64 { return int64(t.Second()) }),
+		"weekday": timeAccessor("weekday", func(t time.Time) int64 { return int64(t.Weekday()) }),
+		"hours":   durationAccessor("hours", time.Hour),
+		"minutes": durationAccessor("minutes", time.Minute),
+		"seconds": durationAccessor("seconds", time.Second),
+		"millis":  durationAccessor("millis", time.Millisecond),
+	})
+	typeModules[object.TimeObject] = module
+	typeModules[object.DurationObject] = module
+}
+
+// timeAccessor creates a builtin returning a component of a time
+func timeAccessor(name string, get func(time.Time) int64) *object.Builtin {
+	return &object.Builtin{
+		Function: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newIllegalStateException(fmt.Sprintf("time.%s takes 1 argument; %d were provided.", name, len(args)))
+			}
+			t, err := timeArgument("time."+name, args[0])
+			if err != nil {
+				return err
+			}
+			return &object.Integer{Value: get(t)}
+		},
+	}
+}
+
+// durationAccessor creates a builtin returning a duration as a whole number of units
+func durationAccessor(name string, unit time.Duration) *object.Builtin {
+	return &object.Builtin{
+		Function: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newIllegalStateException(fmt.Sprintf("time.%s takes 1 argument; %d were provided.", name, len(args)))
+			}
+			d, ok := args[0].(*object.Duration)
+			if !ok {
+				return newIllegalStateException(fmt.Sprintf("time.%s: %s is not a duration.", name, args[0].Inspect()))
+			}
+			return &object.Integer{Value: int64(d.Value / unit)}
+		},
+	}
+}
+
+func timeArgument(name string, arg object.Object) (time.Time, object.Object) {
+	t, ok := arg.(*object.Time)
+	if !ok {
+		return time.Time{}, newIllegalStateException(fmt.Sprintf("%s: %s is not a time.", name, arg.Inspect()))
+	}
+	return t.Value, nil
+}
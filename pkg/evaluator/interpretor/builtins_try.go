@@ -0,0 +1,69 @@
+package interpretor
+
+import (
+	"fmt"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+// tryDepth is the number of calls to try being evaluated
+var tryDepth = 0
+
+// raised carries an error which was encountered inside try up to it
+type raised struct {
+	err *object.Error
+}
+
+func init() {
+	registerBuiltins(map[string]*object.Builtin{
+		"try": {
+			Function: func(args ...object.Object) object.Object {
+				if len(args) < 1 || len(args) > 2 {
+					return newIllegalStateException(fmt.Sprintf("try takes 1 to 2 arguments; %d were provided.", len(args)))
+				}
+				err, res := tryCall(args[0])
+				if err == nil {
+					return res
+				}
+				if len(args) == 1 {
+					return Null
+				}
+				return applyFunction(args[1], []object.Object{errorHash(err)})
+			},
+		},
+	})
+}
+
+// tryCall calls fn without arguments and returns the first error encountered while doing so
+func tryCall(fn object.Object) (err *object.Error, res object.Object) {
+	tryDepth++
+	defer func() {
+		tryDepth--
+		if r := recover(); r != nil {
+			caught, ok := r.(raised)
+			if !ok {
+				panic(r)
+			}
+			err = caught.err
+		}
+	}()
+	res = applyFunction(fn, []object.Object{})
+	if e, ok := res.(*object.Error); ok {
+		return e, nil
+	}
+	return nil, res
+}
+
+// errorHash describes an error to the handler of try
+func errorHash(err *object.Error) *object.Hash {
+	kind := err.Kind
+	if kind == "" {
+		kind = "Error"
+	}
+	res := object.NewHash()
+	res.Set("message", &object.String{Value: err.Message})
+	res.Set("kind", &object.String{Value: kind})
+	res.Set("line", &object.Integer{Value: int64(err.Line)})
+	res.Set("column", &object.Integer{Value: int64(err.Col)})
+	return res
+}
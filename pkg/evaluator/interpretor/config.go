@@ -0,0 +1,122 @@
+package interpretor
+
+import (
+	"io"
+	"math/rand"
+	"os"
+	"time"
+)
+
+// Sandbox restricts what scripts are allowed to do on the host system
+type Sandbox struct {
+	// AllowRead permits reading files and listing directories
+	AllowRead bool
+	// AllowWrite permits creating, modifying and removing files
+	AllowWrite bool
+	// AllowExec permits running other programs
+	AllowExec bool
+	// Root confines file system access to a directory when not empty
+	Root string
+}
+
+// Config holds the settings the interpreter runs with
+type Config struct {
+	Sandbox Sandbox
+	// Args are the arguments passed to the script
+	Args []string
+	// Stdin is read by the input builtins
+	Stdin io.Reader
+	// Exit terminates the program with the given status code
+	Exit func(code int)
+	// Clock tells the time to the time module
+	Clock Clock
+	// Random is the source of the random module
+	Random *rand.Rand
+	// Decimal configures decimal arithmetic
+	Decimal DecimalContext
+	// Strict disables implicit conversions between the types of operands in every program,
+	// while the "use strict" pragma only disables them in its own program
+	Strict bool
+	// Arithmetic decides what happens on integer overflow and division by zero
+	Arithmetic ArithmeticMode
+}
+
+// ArithmeticMode decides what happens on integer overflow and division by zero
+type ArithmeticMode string
+
+const (
+	// Promote promotes integers which overflow to arbitrary precision, division by zero raises an error
+	Promote ArithmeticMode = "promote"
+	// Checked raises an error on overflow and on division by zero
+	Checked ArithmeticMode = "checked"
+	// Legacy wraps integers around on overflow, division by zero evaluates to null
+	Legacy ArithmeticMode = "legacy"
+)
+
+// RoundingMode decides how decimals are rounded when decimal places are dropped
+type RoundingMode string
+
+const (
+	// HalfEven rounds to the nearest neighbour, ties to the even one
+	HalfEven RoundingMode = "halfEven"
+	// HalfUp rounds to the nearest neighbour, ties away from zero
+	HalfUp RoundingMode = "halfUp"
+	// HalfDown rounds to the nearest neighbour, ties towards zero
+	HalfDown RoundingMode = "halfDown"
+	// Up rounds away from zero
+	Up RoundingMode = "up"
+	// Down rounds towards zero
+	Down RoundingMode = "down"
+	// Ceiling rounds towards positive infinity
+	Ceiling RoundingMode = "ceiling"
+	// Floor rounds towards negative infinity
+	Floor RoundingMode = "floor"
+)
+
+// DecimalContext configures decimal arithmetic
+type DecimalContext struct {
+	// Precision is the number of decimal places kept when a result cannot be exact
+	Precision int32
+	// Rounding is used when decimal places are dropped
+	Rounding RoundingMode
+}
+
+// Clock tells the time and waits for time to pass
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// systemClock is the Clock of the host system
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// DefaultConfig returns the configuration used when none is given
+func DefaultConfig() *Config {
+	return &Config{
+		Sandbox: Sandbox{
+			AllowRead:  true,
+			AllowWrite: true,
+		},
+		Args:   []string{},
+		Stdin:  os.Stdin,
+		Exit:   os.Exit,
+		Clock:  systemClock{},
+		Random: rand.New(rand.NewSource(time.Now().UnixNano())),
+		Decimal: DecimalContext{
+			Precision: 16,
+			Rounding:  HalfEven,
+		},
+		Arithmetic: Promote,
+	}
+}
+
+// Settings is the configuration used by Eval and the builtins
+var Settings = DefaultConfig()
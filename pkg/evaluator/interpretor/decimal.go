@@ -0,0 +1,152 @@
+package interpretor
+
+import (
+	"math/big"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+var bigTen = big.NewInt(10)
+
+// pow10 returns 10^n
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// rescale returns the unscaled value of d at a larger scale
+func rescale(d *object.Decimal, scale int32) *big.Int {
+	return new(big.Int).Mul(d.Unscaled, pow10(scale-d.Scale))
+}
+
+// alignDecimals returns the unscaled values of lhs and rhs at their common scale
+func alignDecimals(lhs, rhs *object.Decimal) (*big.Int, *big.Int, int32) {
+	scale := lhs.Scale
+	if rhs.Scale > scale {
+		scale = rhs.Scale
+	}
+	return rescale(lhs, scale), rescale(rhs, scale), scale
+}
+
+func addDecimals(lhs, rhs *object.Decimal) *object.Decimal {
+	l, r, scale := alignDecimals(lhs, rhs)
+	return &object.Decimal{Unscaled: l.Add(l, r), Scale: scale}
+}
+
+func subtractDecimals(lhs, rhs *object.Decimal) *object.Decimal {
+	l, r, scale := alignDecimals(lhs, rhs)
+	return &object.Decimal{Unscaled: l.Sub(l, r), Scale: scale}
+}
+
+// multiplyDecimals is exact unless the result has more decimal places than the precision
+func multiplyDecimals(lhs, rhs *object.Decimal) *object.Decimal {
+	res := &object.Decimal{
+		Unscaled: new(big.Int).Mul(lhs.Unscaled, rhs.Unscaled),
+		Scale:    lhs.Scale + rhs.Scale,
+	}
+	return roundDecimal(res, Settings.Decimal.Precision, Settings.Decimal.Rounding)
+}
+
+// divideDecimals divides to the precision, trailing zeros beyond the scale of the operands are dropped.
+// rhs must not be zero.
+func divideDecimals(lhs, rhs *object.Decimal) *object.Decimal {
+	precision := Settings.Decimal.Precision
+	// lhs / rhs = (lhs.Unscaled * 10^k) / rhs.Unscaled * 10^-precision
+	num := new(big.Int).Set(lhs.Unscaled)
+	den := new(big.Int).Set(rhs.Unscaled)
+	if k := precision + rhs.Scale - lhs.Scale; k >= 0 {
+		num.Mul(num, pow10(k))
+	} else {
+		den.Mul(den, pow10(-k))
+	}
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	res := &object.Decimal{Unscaled: roundQuotient(quo, rem, den, Settings.Decimal.Rounding), Scale: precision}
+
+	minScale := lhs.Scale
+	if rhs.Scale > minScale {
+		minScale = rhs.Scale
+	}
+	return stripZeros(res, minScale)
+}
+
+// roundDecimal rounds d to at most scale decimal places
+func roundDecimal(d *object.Decimal, scale int32, mode RoundingMode) *object.Decimal {
+	if d.Scale <= scale {
+		return d
+	}
+	den := pow10(d.Scale - scale)
+	quo, rem := new(big.Int).QuoRem(d.Unscaled, den, new(big.Int))
+	return &object.Decimal{Unscaled: roundQuotient(quo, rem, den, mode), Scale: scale}
+}
+
+// roundQuotient rounds the truncated quotient quo given the remainder rem of the division by den
+func roundQuotient(quo, rem, den *big.Int, mode RoundingMode) *big.Int {
+	if rem.Sign() == 0 {
+		return quo
+	}
+	// sign of the exact result
+	sign := rem.Sign() * den.Sign()
+	// compare the remainder to half of the divisor
+	half := new(big.Int).Abs(rem)
+	half.Mul(half, big.NewInt(2))
+	cmp := half.Cmp(new(big.Int).Abs(den))
+
+	awayFromZero := false
+	switch mode {
+	case Up:
+		awayFromZero = true
+	case Down:
+		awayFromZero = false
+	case Ceiling:
+		awayFromZero = sign > 0
+	case Floor:
+		awayFromZero = sign < 0
+	case HalfUp:
+		awayFromZero = cmp >= 0
+	case HalfDown:
+		awayFromZero = cmp > 0
+	default:
+		awayFromZero = cmp > 0 || (cmp == 0 && quo.Bit(0) == 1)
+	}
+	if awayFromZero {
+		return quo.Add(quo, big.NewInt(int64(sign)))
+	}
+	return quo
+}
+
+// stripZeros drops trailing zeros while the scale is larger than minScale
+func stripZeros(d *object.Decimal, minScale int32) *object.Decimal {
+	unscaled := new(big.Int).Set(d.Unscaled)
+	scale := d.Scale
+	rem := new(big.Int)
+	for scale > minScale {
+		quo, _ := new(big.Int).QuoRem(unscaled, bigTen, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		unscaled = quo
+		scale--
+	}
+	return &object.Decimal{Unscaled: unscaled, Scale: scale}
+}
+
+func compareDecimals(lhs, rhs *object.Decimal) int {
+	l, r, _ := alignDecimals(lhs, rhs)
+	return l.Cmp(r)
+}
+
+// decimalFromInteger converts an integer to a decimal without decimal places
+func decimalFromInteger(integer *object.Integer) *object.Decimal {
+	return &object.Decimal{Unscaled: new(big.Int).Set(integer.BigValue()), Scale: 0}
+}
+
+// evalAsDecimal converts decimals and integers to a decimal
+func evalAsDecimal(operand object.Object) (*object.Decimal, bool) {
+	switch operand := operand.(type) {
+	case *object.Decimal:
+		return operand, true
+	case *object.Integer:
+		return decimalFromInteger(operand), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,80 @@
+package interpretor
+
+import (
+	"fmt"
+
+	"github.com/latiif/lail/pkg/ast"
+	"github.com/latiif/lail/pkg/object"
+)
+
+// binding is a value matched to an identifier of a pattern
+type binding struct {
+	name  string
+	value object.Object
+}
+
+// bind binds val to the identifiers of pattern in env, it returns an error if the shape of val does not match the pattern.
+// Nothing is bound unless all of val matches, so a failed destructuring leaves env as it was.
+func bind(pattern ast.Pattern, val object.Object, env *object.Env) object.Object {
+	bindings, err := match(pattern, val, nil)
+	if err != nil {
+		return err
+	}
+	for _, b := range bindings {
+		env.Set(b.name, b.value)
+	}
+	return val
+}
+
+// match appends the bindings of the identifiers of pattern to bindings
+func match(pattern ast.Pattern, val object.Object, bindings []binding) ([]binding, object.Object) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		return append(bindings, binding{name: pattern.Value, value: val}), nil
+	case *ast.ArrayPattern:
+		return matchArray(pattern, val, bindings)
+	case *ast.HashPattern:
+		return matchHash(pattern, val, bindings)
+	}
+	return nil, newIllegalStateException(fmt.Sprintf("Cannot bind to %s", pattern.String()))
+}
+
+func matchArray(pattern *ast.ArrayPattern, val object.Object, bindings []binding) ([]binding, object.Object) {
+	arr, ok := val.(*object.Array)
+	if !ok {
+		return nil, newIllegalStateException(fmt.Sprintf("Cannot destructure %s as %s", val.Type(), pattern.String()))
+	}
+	if pattern.Rest == nil && len(arr.Value) != len(pattern.Elements) {
+		return nil, newIllegalStateException(fmt.Sprintf("%s expected %d element(s); got %d", pattern.String(), len(pattern.Elements), len(arr.Value)))
+	}
+	if len(arr.Value) < len(pattern.Elements) {
+		return nil, newIllegalStateException(fmt.Sprintf("%s expected at least %d element(s); got %d", pattern.String(), len(pattern.Elements), len(arr.Value)))
+	}
+	for i, element := range pattern.Elements {
+		var err object.Object
+		if bindings, err = match(element, arr.Value[i], bindings); err != nil {
+			return nil, err
+		}
+	}
+	if pattern.Rest != nil {
+		rest := make([]object.Object, len(arr.Value)-len(pattern.Elements))
+		copy(rest, arr.Value[len(pattern.Elements):])
+		bindings = append(bindings, binding{name: pattern.Rest.Value, value: &object.Array{Value: rest}})
+	}
+	return bindings, nil
+}
+
+func matchHash(pattern *ast.HashPattern, val object.Object, bindings []binding) ([]binding, object.Object) {
+	hash, ok := val.(*object.Hash)
+	if !ok {
+		return nil, newIllegalStateException(fmt.Sprintf("Cannot destructure %s as %s", val.Type(), pattern.String()))
+	}
+	for _, key := range pattern.Keys {
+		v, ok := hash.Get(key.Value)
+		if !ok {
+			return nil, newIllegalStateException(fmt.Sprintf("%s expected key %q", pattern.String(), key.Value))
+		}
+		bindings = append(bindings, binding{name: key.Value, value: v})
+	}
+	return bindings, nil
+}
@@ -3,21 +3,22 @@ package interpretor
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/latiif/lail/pkg/object"
 )
 
-func evalAsInteger(operand object.Object) int64 {
+func evalAsInteger(operand object.Object) *object.Integer {
 	switch operand.Type() {
 	case object.IntegerObject:
-		return operand.(*object.Integer).Value
+		return operand.(*object.Integer)
 	case object.BooleanObject:
 		if operand.(*object.Boolean).Value {
-			return 1
+			return &object.Integer{Value: 1}
 		}
-		return 0
+		return &object.Integer{Value: 0}
 	default:
-		return 0
+		return &object.Integer{Value: 0}
 	}
 }
 
@@ -26,9 +27,7 @@ func evalAsInteger(operand object.Object) int64 {
 func evalInfixMinus(lhs, rhs object.Object) object.Object {
 	// <int> - <int> = substraction
 	if rhs.Type() == object.IntegerObject && lhs.Type() == object.IntegerObject {
-		return &object.Integer{
-			Value: lhs.(*object.Integer).Value - rhs.(*object.Integer).Value,
-		}
+		return subtractIntegers(lhs.(*object.Integer), rhs.(*object.Integer))
 	}
 
 	// <str> - <str1> = replace first instance of str1 in str
@@ -38,7 +37,7 @@ func evalInfixMinus(lhs, rhs object.Object) object.Object {
 		}
 	}
 
-	return &object.Integer{Value: evalAsInteger(lhs) - evalAsInteger(rhs)}
+	return subtractIntegers(evalAsInteger(lhs), evalAsInteger(rhs))
 
 }
 
@@ -51,9 +50,7 @@ func evalInfixPlus(lhs, rhs object.Object) object.Object {
 
 	// <int> + <int> = addition
 	if rhs.Type() == object.IntegerObject && lhs.Type() == object.IntegerObject {
-		return &object.Integer{
-			Value: lhs.(*object.Integer).Value + rhs.(*object.Integer).Value,
-		}
+		return addIntegers(lhs.(*object.Integer), rhs.(*object.Integer))
 	}
 
 	// <arr> + <arr> = [<arr>, <arr>]
@@ -76,21 +73,250 @@ func evalInfixPlus(lhs, rhs object.Object) object.Object {
 			Value: fmt.Sprintf("%s%s", lhs.Inspect(), rhs.(*object.String).Value),
 		}
 	}
-	return &object.Integer{Value: evalAsInteger(lhs) + evalAsInteger(rhs)}
+	return addIntegers(evalAsInteger(lhs), evalAsInteger(rhs))
+
+}
+
+// equal checks for deep equality. Outside strict mode, a string operand also equals a number, boolean or null
+// which is displayed the same, e.g. 1 == "1", as when + converts them to strings; elements of arrays are not converted
+func equal(lhs, rhs object.Object, strict bool) bool {
+	if lhs.Equals(rhs) {
+		return true
+	}
+	if strict {
+		return false
+	}
+	lType, rType := lhs.Type(), rhs.Type()
+	if lType == object.StringObject && isScalar(rType) || rType == object.StringObject && isScalar(lType) {
+		return lhs.Inspect() == rhs.Inspect()
+	}
+	return false
+}
+
+// isScalar reports whether values of the type are compared loosely with strings outside strict mode
+func isScalar(t object.ObjectType) bool {
+	return isNumber(t) || t == object.BooleanObject || t == object.NullObject
+}
+
+// strictlyCompatible reports whether operator can be applied to operands of these types in strict mode.
+// Numbers can be mixed except floats with decimals, and times and durations are checked by evalInfixTime.
+func strictlyCompatible(lhs object.Object, operator string, rhs object.Object) bool {
+	if operator == "==" || operator == "!=" {
+		return true
+	}
+	lType, rType := lhs.Type(), rhs.Type()
+	if isNumber(lType) && isNumber(rType) {
+		return !(lType == object.FloatObject && rType == object.DecimalObject) &&
+			!(lType == object.DecimalObject && rType == object.FloatObject)
+	}
+	if lType != rType && (lType == object.StringObject || rType == object.StringObject) {
+		return false
+	}
+	for _, t := range []object.ObjectType{lType, rType} {
+		if t == object.TimeObject || t == object.DurationObject {
+			return true
+		}
+	}
+	if lType != rType {
+		return false
+	}
+	switch lType {
+	case object.StringObject:
+		return operator == "+" || operator == "-"
+	case object.ArrayObject:
+		return operator == "+"
+	default:
+		return false
+	}
+}
+
+func isNumber(t object.ObjectType) bool {
+	return t == object.IntegerObject || t == object.FloatObject || t == object.DecimalObject
+}
+
+// evalInfixTime evaluates operators on times and durations, ok is false if neither operand is one
+// <time> + <duration> = <time>
+// <time> - <time> = <duration>
+// <duration> * <int> = <duration>
+func evalInfixTime(lhs object.Object, operator string, rhs object.Object) (res object.Object, ok bool) {
+	lTime, lIsTime := lhs.(*object.Time)
+	rTime, rIsTime := rhs.(*object.Time)
+	lDuration, lIsDuration := lhs.(*object.Duration)
+	rDuration, rIsDuration := rhs.(*object.Duration)
+	if !lIsTime && !rIsTime && !lIsDuration && !rIsDuration {
+		return nil, false
+	}
+	// concatenation and equality of mismatched types are handled as for other types
+	if lhs.Type() == object.StringObject || rhs.Type() == object.StringObject {
+		return nil, false
+	}
+	if lhs.Type() != rhs.Type() && (operator == "==" || operator == "!=") {
+		return nil, false
+	}
+
+	switch {
+	case lIsTime && rIsDuration && operator == "+":
+		return &object.Time{Value: lTime.Value.Add(rDuration.Value)}, true
+	case lIsDuration && rIsTime && operator == "+":
+		return &object.Time{Value: rTime.Value.Add(lDuration.Value)}, true
+	case lIsTime && rIsDuration && operator == "-":
+		return &object.Time{Value: lTime.Value.Add(-rDuration.Value)}, true
+	case lIsTime && rIsTime:
+		if operator == "-" {
+			return &object.Duration{Value: lTime.Value.Sub(rTime.Value)}, true
+		}
+		return evalComparison(operator, compareTimes(lTime.Value, rTime.Value)), true
+	case lIsDuration && rIsDuration:
+		switch operator {
+		case "+":
+			return &object.Duration{Value: lDuration.Value + rDuration.Value}, true
+		case "-":
+			return &object.Duration{Value: lDuration.Value - rDuration.Value}, true
+		}
+		return evalComparison(operator, compareInts(int64(lDuration.Value), int64(rDuration.Value))), true
+	case lIsDuration && rhs.Type() == object.IntegerObject && operator == "*":
+		return toDuration(multiplyIntegers(&object.Integer{Value: int64(lDuration.Value)}, rhs.(*object.Integer))), true
+	case lIsDuration && rhs.Type() == object.IntegerObject && operator == "/":
+		return toDuration(divideIntegers(&object.Integer{Value: int64(lDuration.Value)}, rhs.(*object.Integer))), true
+	}
+	return newIncompatibleTypes(operator, lhs, rhs), true
+}
+
+// evalComparison evaluates a comparison operator given the result of comparing its operands (-1, 0 or 1)
+func evalComparison(operator string, cmp int) object.Object {
+	switch operator {
+	case ">":
+		return getBooleanObject(cmp > 0)
+	case "<":
+		return getBooleanObject(cmp < 0)
+	case ">=":
+		return getBooleanObject(cmp >= 0)
+	case "<=":
+		return getBooleanObject(cmp <= 0)
+	case "==":
+		return getBooleanObject(cmp == 0)
+	case "!=":
+		return getBooleanObject(cmp != 0)
+	default:
+		return Null
+	}
+}
+
+func compareTimes(lhs, rhs time.Time) int {
+	switch {
+	case lhs.Before(rhs):
+		return -1
+	case lhs.After(rhs):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareInts(lhs, rhs int64) int {
+	switch {
+	case lhs < rhs:
+		return -1
+	case lhs > rhs:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// evalInfixFloat evaluates arithmetic and comparisons where an operand is a float,
+// the other operand is converted to a float. ok is false if neither operand is a float.
+// 1 + 0.5 => 1.5
+func evalInfixFloat(lhs object.Object, operator string, rhs object.Object) (res object.Object, ok bool) {
+	if lhs.Type() != object.FloatObject && rhs.Type() != object.FloatObject {
+		return nil, false
+	}
+	lValue, lOk := evalAsFloat(lhs)
+	rValue, rOk := evalAsFloat(rhs)
+	// concatenation and equality of other types are handled as for other types
+	if !lOk || !rOk {
+		if operator == "-" || operator == "*" || operator == "/" {
+			return newIncompatibleTypes(operator, lhs, rhs), true
+		}
+		return nil, false
+	}
 
+	switch operator {
+	case "+":
+		return &object.Float{Value: lValue + rValue}, true
+	case "-":
+		return &object.Float{Value: lValue - rValue}, true
+	case "*":
+		return &object.Float{Value: lValue * rValue}, true
+	case "/":
+		if rValue == 0 {
+			return divisionByZero(), true
+		}
+		return &object.Float{Value: lValue / rValue}, true
+	}
+	return evalComparison(operator, compareFloats(lValue, rValue)), true
 }
 
-// evalInfixEquality checks for equality regardless of type
-// 1 == "1" => true
-func evalInfixEquality(lhs, rhs object.Object) object.Object {
-	return &object.Boolean{
-		Value: lhs.Inspect() == rhs.Inspect(),
+// evalAsFloat converts numbers and booleans to a float64
+func evalAsFloat(operand object.Object) (float64, bool) {
+	if operand.Type() == object.BooleanObject {
+		return float64(evalAsInteger(operand).Value), true
 	}
+	return numberArgument(operand)
 }
 
-// evalInfixInequality checks for inequality irragrdless of type
-func evalInfixInequality(lhs, rhs object.Object) object.Object {
-	return &object.Boolean{
-		Value: lhs.Inspect() != rhs.Inspect(),
+func compareFloats(lhs, rhs float64) int {
+	switch {
+	case lhs < rhs:
+		return -1
+	case lhs > rhs:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// evalInfixDecimal evaluates arithmetic and comparisons where an operand is a decimal,
+// integers are converted to decimals but floats are not, as they are inexact.
+// ok is false if neither operand is a decimal.
+func evalInfixDecimal(lhs object.Object, operator string, rhs object.Object) (res object.Object, ok bool) {
+	if lhs.Type() != object.DecimalObject && rhs.Type() != object.DecimalObject {
+		return nil, false
+	}
+	lValue, lOk := evalAsDecimal(lhs)
+	rValue, rOk := evalAsDecimal(rhs)
+	if !lOk || !rOk {
+		// concatenation and equality of other types are handled as for other types
+		if lhs.Type() == object.StringObject || rhs.Type() == object.StringObject || operator == "==" || operator == "!=" {
+			return nil, false
+		}
+		return newIncompatibleTypes(operator, lhs, rhs), true
+	}
+
+	switch operator {
+	case "+":
+		return addDecimals(lValue, rValue), true
+	case "-":
+		return subtractDecimals(lValue, rValue), true
+	case "*":
+		return multiplyDecimals(lValue, rValue), true
+	case "/":
+		if rValue.Unscaled.Sign() == 0 {
+			return divisionByZero(), true
+		}
+		return divideDecimals(lValue, rValue), true
+	}
+	return evalComparison(operator, compareDecimals(lValue, rValue)), true
+}
+
+// toDuration converts the result of integer arithmetic on nanoseconds back to a duration
+func toDuration(res object.Object) object.Object {
+	integer, ok := res.(*object.Integer)
+	if !ok {
+		return res
+	}
+	if integer.Big != nil {
+		return newArithmeticError("Duration overflow: result does not fit in 64 bits of nanoseconds")
 	}
+	return &object.Duration{Value: time.Duration(integer.Value)}
 }
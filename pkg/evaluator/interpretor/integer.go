@@ -0,0 +1,150 @@
+package interpretor
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/latiif/lail/pkg/object"
+)
+
+// Integer arithmetic is done on int64, what happens when it overflows depends on Settings.Arithmetic.
+// By default results are promoted to big.Int, and demoted back to int64 whenever they fit.
+
+func addIntegers(lhs, rhs *object.Integer) object.Object {
+	if lhs.Big == nil && rhs.Big == nil {
+		a, b := lhs.Value, rhs.Value
+		if (b <= 0 || a <= math.MaxInt64-b) && (b >= 0 || a >= math.MinInt64-b) {
+			return &object.Integer{Value: a + b}
+		}
+		return overflow("+", a+b, new(big.Int).Add(lhs.BigValue(), rhs.BigValue()))
+	}
+	return object.NewBigInteger(new(big.Int).Add(lhs.BigValue(), rhs.BigValue()))
+}
+
+func subtractIntegers(lhs, rhs *object.Integer) object.Object {
+	if lhs.Big == nil && rhs.Big == nil {
+		a, b := lhs.Value, rhs.Value
+		if (b >= 0 || a <= math.MaxInt64+b) && (b <= 0 || a >= math.MinInt64+b) {
+			return &object.Integer{Value: a - b}
+		}
+		return overflow("-", a-b, new(big.Int).Sub(lhs.BigValue(), rhs.BigValue()))
+	}
+	return object.NewBigInteger(new(big.Int).Sub(lhs.BigValue(), rhs.BigValue()))
+}
+
+func multiplyIntegers(lhs, rhs *object.Integer) object.Object {
+	if lhs.Big == nil && rhs.Big == nil {
+		if res, ok := multiplyInt64(lhs.Value, rhs.Value); ok {
+			return &object.Integer{Value: res}
+		}
+		return overflow("*", lhs.Value*rhs.Value, new(big.Int).Mul(lhs.BigValue(), rhs.BigValue()))
+	}
+	return object.NewBigInteger(new(big.Int).Mul(lhs.BigValue(), rhs.BigValue()))
+}
+
+// multiplyInt64 reports whether a * b fits in an int64
+func multiplyInt64(a, b int64) (int64, bool) {
+	res := a * b
+	ok := a == 0 || (res/a == b && !(a == -1 && b == math.MinInt64) && !(b == -1 && a == math.MinInt64))
+	return res, ok
+}
+
+// maxPowBits limits the size of the integers math.pow computes, larger powers take too long
+const maxPowBits = 1 << 20
+
+// powerIntegers raises base to a non-negative exponent, overflowing the way * does
+func powerIntegers(base, exp *object.Integer) object.Object {
+	if base.Big == nil && exp.Big == nil {
+		res, wrapped, ok := int64(1), int64(1), true
+		for b, e := base.Value, exp.Value; e > 0; e >>= 1 {
+			if e&1 == 1 {
+				wrapped *= b
+				if ok {
+					res, ok = multiplyInt64(res, b)
+				}
+			}
+			if e > 1 {
+				var square bool
+				b, square = multiplyInt64(b, b)
+				ok = ok && square
+			}
+		}
+		if ok {
+			return &object.Integer{Value: res}
+		}
+		if Settings.Arithmetic != Promote {
+			// the exact result is only needed to promote
+			return overflow("math.pow", wrapped, nil)
+		}
+	}
+	abs := new(big.Int).Abs(base.BigValue())
+	if abs.BitLen() > 1 {
+		minBits := new(big.Int).Mul(big.NewInt(int64(abs.BitLen()-1)), exp.BigValue())
+		if minBits.Cmp(big.NewInt(maxPowBits)) > 0 {
+			return newArithmeticError(fmt.Sprintf("Integer overflow: result of math.pow exceeds %d bits", maxPowBits))
+		}
+	}
+	return object.NewBigInteger(new(big.Int).Exp(base.BigValue(), exp.BigValue(), nil))
+}
+
+// divideIntegers truncates towards zero
+func divideIntegers(lhs, rhs *object.Integer) object.Object {
+	if isZero(rhs) {
+		return divisionByZero()
+	}
+	if lhs.Big == nil && rhs.Big == nil {
+		if lhs.Value == math.MinInt64 && rhs.Value == -1 {
+			return overflow("/", math.MinInt64, new(big.Int).Neg(lhs.BigValue()))
+		}
+		return &object.Integer{Value: lhs.Value / rhs.Value}
+	}
+	return object.NewBigInteger(new(big.Int).Quo(lhs.BigValue(), rhs.BigValue()))
+}
+
+func negateInteger(operand *object.Integer) object.Object {
+	return subtractIntegers(&object.Integer{Value: 0}, operand)
+}
+
+// overflow handles an int64 operation whose exact result does not fit in an int64
+func overflow(operator string, wrapped int64, exact *big.Int) object.Object {
+	switch Settings.Arithmetic {
+	case Checked:
+		return newArithmeticError(fmt.Sprintf("Integer overflow: result of %s does not fit in 64 bits", operator))
+	case Legacy:
+		return &object.Integer{Value: wrapped}
+	default:
+		return object.NewBigInteger(exact)
+	}
+}
+
+// divisionByZero is the result of dividing by zero
+func divisionByZero() object.Object {
+	if Settings.Arithmetic == Legacy {
+		return Null
+	}
+	return newArithmeticError("Division by zero")
+}
+
+// int64Argument validates an argument of a builtin which must be an integer that fits in an int64, e.g. a count or an exit code
+func int64Argument(name string, arg object.Object) (int64, object.Object) {
+	integer, ok := arg.(*object.Integer)
+	if !ok {
+		return 0, newIllegalStateException(fmt.Sprintf("%s: %s is not an integer.", name, arg.Inspect()))
+	}
+	if integer.Big != nil {
+		return 0, newIllegalStateException(fmt.Sprintf("%s: %s is out of range", name, arg.Inspect()))
+	}
+	return integer.Value, nil
+}
+
+func isZero(operand *object.Integer) bool {
+	return operand.Big == nil && operand.Value == 0
+}
+
+func compareIntegers(lhs, rhs *object.Integer) int {
+	if lhs.Big == nil && rhs.Big == nil {
+		return compareInts(lhs.Value, rhs.Value)
+	}
+	return lhs.BigValue().Cmp(rhs.BigValue())
+}
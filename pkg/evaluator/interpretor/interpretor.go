@@ -1,7 +1,9 @@
 package interpretor
 
 import (
+	"bytes"
 	"fmt"
+	"math/big"
 
 	"github.com/latiif/lail/pkg/ast"
 	"github.com/latiif/lail/pkg/token"
@@ -38,6 +40,13 @@ func Eval(node ast.Node, env *object.Env) object.Object {
 		if encounteredError(rhs) {
 			return Null
 		}
+		if node.Pattern != nil {
+			res := bind(node.Pattern, rhs, env)
+			if encounteredError(res) {
+				return Null
+			}
+			return res
+		}
 		return env.Set(node.Name.Value, rhs)
 	case *ast.Identifier:
 		res := evalIdentifier(node, env)
@@ -58,13 +67,30 @@ func Eval(node ast.Node, env *object.Env) object.Object {
 		}
 		return res
 	case *ast.IntegerLiteral:
+		if node.Big != nil {
+			return object.NewBigInteger(node.Big)
+		}
 		return &object.Integer{
 			Value: node.Value,
 		}
+	case *ast.FloatLiteral:
+		return &object.Float{
+			Value: node.Value,
+		}
+	case *ast.DecimalLiteral:
+		res, ok := object.ParseDecimal(node.Value)
+		if !ok {
+			return newIllegalStateException(fmt.Sprintf("Invalid decimal: %s", node.Value))
+		}
+		return res
 	case *ast.StringLiteral:
 		return &object.String{
 			Value: node.Value,
 		}
+	case *ast.InterpolatedString:
+		return evalInterpolatedString(node, env)
+	case *ast.NullLiteral:
+		return Null
 	case *ast.Boolean:
 		res := getBooleanObject(node.Value)
 		if encounteredError(res) {
@@ -95,7 +121,7 @@ func Eval(node ast.Node, env *object.Env) object.Object {
 		if encounteredError(rhs) {
 			return Null
 		}
-		return evalPrefixExpression(node.Operator, rhs)
+		return atToken(evalPrefixExpression(node.Operator, rhs), node.Token)
 	case *ast.InfixExpression:
 		// If it is assignment expression
 		if node.Operator == token.Assign {
@@ -111,33 +137,48 @@ func Eval(node ast.Node, env *object.Env) object.Object {
 			return env.Set(id.Value, rhs)
 		}
 		lhs := Eval(node.Left, env)
+		// the right hand side of ?? is only evaluated when the left hand side is null
+		if node.Operator == token.Coalesce {
+			if encounteredError(lhs) {
+				return Null
+			}
+			if lhs.Type() != object.NullObject {
+				return lhs
+			}
+			return Eval(node.Right, env)
+		}
 		rhs := Eval(node.Right, env)
 		if encounteredError(rhs) || encounteredError(lhs) {
 			return Null
 		}
-		return evalInfixExpression(lhs, node.Operator, rhs)
+		// the strict pragma only applies to the operators of its own program, not to the programs it imports or is imported by
+		strict := Settings.Strict || node.Strict
+		if strict && !strictlyCompatible(lhs, node.Operator, rhs) {
+			return newIncompatibleTypes(node.Operator, lhs, rhs)
+		}
+		return atToken(evalInfixExpression(lhs, node.Operator, rhs, strict), node.Token)
 	case *ast.Array:
 		elements := evalExpressions(node.Elements, env)
 		return &object.Array{
 			Value: elements,
 		}
+	case *ast.MemberExpression:
+		res, _ := evalMember(node, env)
+		return res
 	case *ast.FunctionLiteral:
 		name := node.Name
 		params := node.Params
 		body := node.Body
 		return &object.Function{
-			Name:   name,
-			Params: params,
-			Body:   body,
-			Env:    env,
+			Name:     name,
+			Params:   params,
+			Defaults: node.Defaults,
+			Rest:     node.Rest,
+			Body:     body,
+			Env:      env,
 		}
 	case *ast.CallExpression:
-		function := Eval(node.Function, env)
-		args := evalExpressions(node.Args, env)
-		res := applyFunction(function, args)
-		if encounteredError(res) {
-			return Null
-		}
+		res, _ := evalCall(node, env)
 		return res
 	}
 	return nil
@@ -162,6 +203,22 @@ func evalProgram(prog *ast.Program, e *object.Env) object.Object {
 	return result
 }
 
+// evalInterpolatedString concatenates the display form of the parts of the string
+func evalInterpolatedString(node *ast.InterpolatedString, env *object.Env) object.Object {
+	var out bytes.Buffer
+	for _, part := range node.Parts {
+		val := Eval(part, env)
+		if val == nil {
+			return newIllegalStateException(fmt.Sprintf("Cannot interpolate ${%s}", part.String()))
+		}
+		if encounteredError(val) {
+			return Null
+		}
+		out.WriteString(val.Inspect())
+	}
+	return &object.String{Value: out.String()}
+}
+
 func getBooleanObject(val bool) *object.Boolean {
 	if val {
 		return True
@@ -181,8 +238,8 @@ func evalPrefixExpression(operator string, operand object.Object) object.Object
 }
 
 func evalBangOperator(operand object.Object) object.Object {
-	if operand.Type() == object.IntegerObject {
-		operand = getBooleanObject(operand.(*object.Integer).Value != 0)
+	if operand.Type() == object.IntegerObject || operand.Type() == object.FloatObject || operand.Type() == object.DecimalObject {
+		operand = getBooleanObject(evalAsBoolean(operand))
 	}
 	switch operand {
 	case True:
@@ -197,17 +254,38 @@ func evalBangOperator(operand object.Object) object.Object {
 }
 
 func evalMinusOperator(operand object.Object) object.Object {
+	if operand.Type() == object.FloatObject {
+		return &object.Float{
+			Value: -operand.(*object.Float).Value,
+		}
+	}
+	if decimal, ok := operand.(*object.Decimal); ok {
+		return &object.Decimal{Unscaled: new(big.Int).Neg(decimal.Unscaled), Scale: decimal.Scale}
+	}
 	if operand.Type() != object.IntegerObject {
 		return Null
 	}
 
-	val := operand.(*object.Integer).Value
-	return &object.Integer{
-		Value: -val,
-	}
+	return negateInteger(operand.(*object.Integer))
 }
 
-func evalInfixExpression(lOperand object.Object, operator string, rOperand object.Object) object.Object {
+func evalInfixExpression(lOperand object.Object, operator string, rOperand object.Object, strict bool) object.Object {
+	// equality has the same rules for all types
+	switch operator {
+	case "==":
+		return getBooleanObject(equal(lOperand, rOperand, strict))
+	case "!=":
+		return getBooleanObject(!equal(lOperand, rOperand, strict))
+	}
+	if res, ok := evalInfixTime(lOperand, operator, rOperand); ok {
+		return res
+	}
+	if res, ok := evalInfixDecimal(lOperand, operator, rOperand); ok {
+		return res
+	}
+	if res, ok := evalInfixFloat(lOperand, operator, rOperand); ok {
+		return res
+	}
 
 	if lOperand.Type() != object.IntegerObject && rOperand.Type() == object.IntegerObject && operator == "-" {
 		return newIncompatibleTypes(operator, lOperand, rOperand)
@@ -222,25 +300,11 @@ func evalInfixExpression(lOperand object.Object, operator string, rOperand objec
 	case "-":
 		return evalInfixMinus(lOperand, rOperand)
 	case "*":
-		return &object.Integer{Value: lValue * rValue}
+		return multiplyIntegers(lValue, rValue)
 	case "/":
-		// division by zero
-		if rValue == 0 {
-			return Null
-		}
-		return &object.Integer{Value: lValue / rValue}
-	case ">":
-		return getBooleanObject(lValue > rValue)
-	case "<":
-		return getBooleanObject(lValue < rValue)
-	case ">=":
-		return getBooleanObject(lValue >= rValue)
-	case "<=":
-		return getBooleanObject(lValue <= rValue)
-	case "!=":
-		return evalInfixInequality(lOperand, rOperand)
-	case "==":
-		return evalInfixEquality(lOperand, rOperand)
+		return divideIntegers(lValue, rValue)
+	case ">", "<", ">=", "<=":
+		return evalComparison(operator, compareIntegers(lValue, rValue))
 
 	default:
 		return Null
@@ -252,7 +316,11 @@ func evalAsBoolean(operand object.Object) bool {
 	case object.BooleanObject:
 		return operand.(*object.Boolean).Value
 	case object.IntegerObject:
-		return operand.(*object.Integer).Value != 0
+		return !isZero(operand.(*object.Integer))
+	case object.FloatObject:
+		return operand.(*object.Float).Value != 0
+	case object.DecimalObject:
+		return operand.(*object.Decimal).Unscaled.Sign() != 0
 	default:
 		return false
 	}
@@ -280,8 +348,125 @@ func evalIdentifier(ident *ast.Identifier, e *object.Env) object.Object {
 		return val
 	}
 
+	if val, ok := modules[ident.Value]; ok {
+		return val
+	}
+
 	return newIllegalStateException(fmt.Sprintf("Undeclared identifier: %s", ident.Value))
 }
+
+// evalMemberExpression evaluates obj.member, which is a value of a hash
+// or an accessor in the module of obj's type called with obj
+func evalMemberExpression(obj object.Object, member string) object.Object {
+	if hash, ok := obj.(*object.Hash); ok {
+		// missing members are null
+		if val, ok := hash.Get(member); ok {
+			return val
+		}
+		return Null
+	}
+	if module, ok := typeModules[obj.Type()]; ok {
+		if accessor, ok := module.Get(member); ok {
+			return applyFunction(accessor, []object.Object{obj})
+		}
+	}
+	return newIllegalStateException(fmt.Sprintf("%s has no member %s", obj.Inspect(), member))
+}
+
+// evalReceiver evaluates the object of a member access or of a dot call. It
+// reports whether ?. short-circuited the chain ending in that object, in which
+// case the rest of the chain is skipped as well, e.g. null?.time.format() is null.
+func evalReceiver(node ast.Expression, env *object.Env) (object.Object, bool) {
+	switch node := node.(type) {
+	case *ast.MemberExpression:
+		return evalMember(node, env)
+	case *ast.CallExpression:
+		if isDotCall(node) {
+			return evalCall(node, env)
+		}
+	}
+	return Eval(node, env), false
+}
+
+func isDotCall(node *ast.CallExpression) bool {
+	return node.Token.Type == token.Dot || node.Token.Type == token.OptionalDot
+}
+
+func evalMember(node *ast.MemberExpression, env *object.Env) (object.Object, bool) {
+	obj, skipped := evalReceiver(node.Object, env)
+	if skipped {
+		return Null, true
+	}
+	if encounteredError(obj) {
+		return Null, false
+	}
+	if obj.Type() == object.NullObject && node.Token.Type == token.OptionalDot {
+		return Null, true
+	}
+	res := evalMemberExpression(obj, node.Member.Value)
+	if encounteredError(res) {
+		return Null, false
+	}
+	return res, false
+}
+
+func evalCall(node *ast.CallExpression, env *object.Env) (object.Object, bool) {
+	var function object.Object
+	var args []object.Object
+	if isDotCall(node) {
+		function, args = evalDotCall(node, env)
+		// ?. on null calls nothing
+		if function == nil {
+			return Null, true
+		}
+	} else {
+		function = Eval(node.Function, env)
+		args = evalExpressions(node.Args, env)
+	}
+	named := make([]namedArgument, len(node.Named))
+	for i, arg := range node.Named {
+		named[i] = namedArgument{name: arg.Name.Value, value: Eval(arg.Value, env)}
+	}
+	res := applyFunctionNamed(function, args, named)
+	if encounteredError(res) {
+		return Null, false
+	}
+	return res, false
+}
+
+// evalDotCall resolves the function called by receiver.name(args).
+// name is looked up as a member of the receiver if it is a hash, then in the enclosing scopes,
+// then in the module of the receiver's type, which is called with the receiver as first argument.
+// The function is nil when ?. is used on a null receiver, or when the chain ending in the receiver was short-circuited.
+func evalDotCall(node *ast.CallExpression, env *object.Env) (object.Object, []object.Object) {
+	if len(node.Args) == 0 {
+		return Eval(node.Function, env), []object.Object{}
+	}
+	receiver, skipped := evalReceiver(node.Args[0], env)
+	// the other arguments are not evaluated when ?. is used on null
+	if skipped || receiver.Type() == object.NullObject && node.Token.Type == token.OptionalDot {
+		return nil, nil
+	}
+	args := append([]object.Object{receiver}, evalExpressions(node.Args[1:], env)...)
+	name, ok := node.Function.(*ast.Identifier)
+	if !ok {
+		return Eval(node.Function, env), args
+	}
+	if hash, ok := args[0].(*object.Hash); ok {
+		if member, ok := hash.Get(name.Value); ok {
+			return member, args[1:]
+		}
+	}
+	if _, ok := env.Get(name.Value); !ok {
+		if module, ok := typeModules[args[0].Type()]; ok {
+			if member, ok := module.Get(name.Value); ok {
+				return member, args
+			}
+		}
+	}
+	return Eval(node.Function, env), args
+}
+
 func evalExpressions(exprs []ast.Expression, e *object.Env) []object.Object {
 	res := make([]object.Object, len(exprs))
 
@@ -295,7 +480,18 @@ var depth = 0
 
 const maxDepth = 99999
 
+// namedArgument is an argument passed by the name of its parameter, e.g. f(x: 1)
+type namedArgument struct {
+	name  string
+	value object.Object
+}
+
 func applyFunction(fn object.Object, args []object.Object) object.Object {
+	return applyFunctionNamed(fn, args, nil)
+}
+
+// applyFunctionNamed applies fn to args and to the arguments passed by name, which only user defined functions accept
+func applyFunctionNamed(fn object.Object, args []object.Object, named []namedArgument) object.Object {
 	depth++
 	defer func() {
 		depth--
@@ -305,31 +501,83 @@ func applyFunction(fn object.Object, args []object.Object) object.Object {
 	}
 	// check if it's a user defined function
 	if function, ok := fn.(*object.Function); ok {
-		if len(function.Params) != len(args) {
-			functionName := "Anonymous function"
-			if function.Name != nil && function.Name.Value != "" {
-				functionName = function.Name.Value
-			}
-			return newIllegalStateException(fmt.Sprintf("%s: function call expected %d parameter(s); got %d argument(s)", functionName, len(function.Params), len(args)))
+		fnExtendedEnv, err := extendFunctionEnv(function, args, named)
+		if err != nil {
+			return err
 		}
-		fnExtendedEnv := extendFunctionEnv(function, args)
 		return unwrapReturnValue(Eval(function.Body, fnExtendedEnv))
 	}
 
 	// check if it's a built in function
 	if function, ok := fn.(*object.Builtin); ok {
+		if len(named) != 0 {
+			return newIllegalStateException(fmt.Sprintf("Builtin functions do not accept named arguments; got %s", named[0].name))
+		}
 		return function.Function(args...)
 	}
 
 	return Null
 }
 
-func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Env {
+// extendFunctionEnv binds the arguments to the parameters, parameters without an argument take their default value
+// and the arguments after the parameters are collected by the rest parameter.
+// It returns an error if the arguments do not match the parameters.
+func extendFunctionEnv(fn *object.Function, args []object.Object, named []namedArgument) (*object.Env, object.Object) {
+	functionName := "Anonymous function"
+	if fn.Name != nil && fn.Name.Value != "" {
+		functionName = fn.Name.Value
+	}
+	if len(args) > len(fn.Params) && fn.Rest == nil {
+		return nil, newIllegalStateException(fmt.Sprintf("%s: function call expected %d parameter(s); got %d argument(s)", functionName, len(fn.Params), len(args)))
+	}
+
+	values := make([]object.Object, len(fn.Params))
+	copy(values, args)
+	for _, arg := range named {
+		i := paramIndex(fn.Params, arg.name)
+		if i < 0 {
+			return nil, newIllegalStateException(fmt.Sprintf("%s: function has no parameter %s", functionName, arg.name))
+		}
+		if values[i] != nil {
+			return nil, newIllegalStateException(fmt.Sprintf("%s: parameter %s got more than one argument", functionName, arg.name))
+		}
+		values[i] = arg.value
+	}
+
 	env := object.NewEnclosedEnv(fn.Env)
 	for i, param := range fn.Params {
-		env.Set(param.Value, args[i])
+		val := values[i]
+		if val == nil {
+			if i >= len(fn.Defaults) || fn.Defaults[i] == nil {
+				return nil, newIllegalStateException(fmt.Sprintf("%s: missing argument for parameter %s", functionName, param.String()))
+			}
+			// defaults are evaluated on every call, and can refer to the parameters before them
+			if val = Eval(fn.Defaults[i], env); val == nil {
+				val = Null
+			}
+		}
+		if res := bind(param, val, env); res.Type() == object.ErrorObject {
+			return nil, res
+		}
 	}
-	return env
+	if fn.Rest != nil {
+		rest := []object.Object{}
+		if len(args) > len(fn.Params) {
+			rest = append(rest, args[len(fn.Params):]...)
+		}
+		env.Set(fn.Rest.Value, &object.Array{Value: rest})
+	}
+	return env, nil
+}
+
+// paramIndex is the index of the parameter named name, parameters which destructure their argument have no name
+func paramIndex(params []ast.Pattern, name string) int {
+	for i, param := range params {
+		if id, ok := param.(*ast.Identifier); ok && id.Value == name {
+			return i
+		}
+	}
+	return -1
 }
 
 func unwrapReturnValue(obj object.Object) object.Object {
@@ -345,14 +593,42 @@ func newIncompatibleTypes(operator string, lhs, rhs object.Object) object.Object
 	}
 }
 
+func newArithmeticError(msg string) object.Object {
+	return &object.Error{
+		Message: fmt.Sprintf("Arithmetic Error: %s.", msg),
+		Kind:    object.ArithmeticError,
+	}
+}
+
+// atToken locates an arithmetic error at the operator which raised it
+func atToken(res object.Object, tok token.Token) object.Object {
+	if err, ok := res.(*object.Error); ok && err.Kind == object.ArithmeticError && err.Line == 0 {
+		err.Line, err.Col = tok.Line, tok.Col
+	}
+	return res
+}
+
 func newIllegalStateException(msg string) object.Object {
 	return &object.Error{
 		Message: fmt.Sprintf("Illegal State: %s.", msg),
 	}
 }
 
+// errorCount is the number of errors encountered so far
+var errorCount = 0
+
+// ErrorCount returns the number of errors encountered since the program started
+func ErrorCount() int {
+	return errorCount
+}
+
 func encounteredError(result object.Object) bool {
 	if result.Type() == object.ErrorObject {
+		// inside try the error unwinds to the handler instead of being reported
+		if tryDepth > 0 {
+			panic(raised{result.(*object.Error)})
+		}
+		errorCount++
 		fmt.Printf("Error: %v\n", result.Inspect())
 		return true
 	}
@@ -1,7 +1,14 @@
 package interpretor
 
 import (
+	"io/ioutil"
+	"math"
+	"math/big"
+	"math/rand"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/latiif/lail/pkg/lexer"
 	"github.com/latiif/lail/pkg/object"
@@ -293,3 +300,1137 @@ func TestBuiltins(t *testing.T) {
 		}
 	}
 }
+
+func TestFileBuiltins(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`writeFile("DIR/a.txt", "one\ntwo\n")`, "true"},
+		{`appendFile("DIR/a.txt", "three")`, "true"},
+		{`readFile("DIR/a.txt")`, "one\ntwo\nthree"},
+		{`exists("DIR/a.txt")`, "true"},
+		{`exists("DIR/b.txt")`, "false"},
+		{`let f = open("DIR/a.txt"); let first = readLine(f); readLine(f); let third = readLine(f); let last = readLine(f); close(f); [first, third, last]`, "[one, three, null]"},
+		{`mkdir("DIR/sub/dir")`, "true"},
+		{`listDir("DIR")`, "[a.txt, sub]"},
+		{`remove("DIR/a.txt"); exists("DIR/a.txt")`, "false"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(strings.Replace(tt.input, "DIR", dir, -1))
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestFileBuiltinsSandbox(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	defer func(previous *Config) { Settings = previous }(Settings)
+	Settings = DefaultConfig()
+	Settings.Sandbox.Root = dir
+	Settings.Sandbox.AllowWrite = false
+
+	tests := []struct {
+		input    string
+		expected object.Object
+	}{
+		{`exists("DIR")`, True},
+		{`exists("DIR/../")`, Null},
+		{`writeFile("DIR/a.txt", "content")`, Null},
+		{`mkdir("DIR/sub")`, Null},
+	}
+
+	for _, tt := range tests {
+		got := testEval(strings.Replace(tt.input, "DIR", dir, -1))
+		if got != tt.expected {
+			t.Errorf("%s: got %v want %v", tt.input, got.Inspect(), tt.expected.Inspect())
+		}
+	}
+}
+
+func TestOSBuiltins(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+	Settings = DefaultConfig()
+	Settings.Args = []string{"a", "b"}
+	exitCode := -1
+	Settings.Exit = func(code int) { exitCode = code }
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`args()`, "[a, b]"},
+		{`args().head()`, "a"},
+		{`setenv("LAIL_TEST_VAR", 42); env("LAIL_TEST_VAR")`, "42"},
+		{`env("LAIL_TEST_UNSET_VAR")`, "null"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+
+	testEval("exit(3)")
+	if exitCode != 3 {
+		t.Errorf("exit code wrong. got=%d, want=3", exitCode)
+	}
+	testEval("exit()")
+	if exitCode != 0 {
+		t.Errorf("exit code wrong. got=%d, want=0", exitCode)
+	}
+}
+
+func TestInputBuiltins(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+
+	tests := []struct {
+		stdin    string
+		input    string
+		expected string
+	}{
+		{"one\ntwo\n", `readLine()`, "one"},
+		{"one\r\ntwo", `readLine(); readLine()`, "two"},
+		{"", `readLine()`, "null"},
+		{"one\ntwo\nthree", `readLine(); let next = lines(); [next(), next(), next()]`, "[two, three, null]"},
+		{"one\ntwo\nthree", `let next = lines(); [next(), readLine(), next()]`, "[one, two, three]"},
+		{"one\ntwo\n", `readAll()`, "one\ntwo\n"},
+		{"", `let next = lines(); next()`, "null"},
+		{"one\n", `let next = lines(); try(fn() { next(1) }, fn(e) { e.message })`, "Illegal State: the iterator returned by lines takes no arguments; 1 were provided."},
+	}
+
+	for _, tt := range tests {
+		Settings = DefaultConfig()
+		Settings.Stdin = strings.NewReader(tt.stdin)
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+
+	// lines reads on demand, so it works on input which never ends
+	Settings = DefaultConfig()
+	Settings.Stdin = endlessReader{}
+	if got := testEval(`let next = lines(); next(); next()`); got.Inspect() != "y" {
+		t.Errorf("lines on endless input: got %q want %q", got.Inspect(), "y")
+	}
+}
+
+// endlessReader reads as an infinite stream of "y" lines
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = "y\n"[i%2]
+	}
+	return len(p), nil
+}
+
+func TestExecBuiltin(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+	Settings = DefaultConfig()
+
+	if got := testEval(`exec("echo", ["hi"])`); got != Null {
+		t.Fatalf("exec should not be permitted by default. got=%s", got.Inspect())
+	}
+
+	Settings.Sandbox.AllowExec = true
+	dir, err := ioutil.TempDir("", "lail")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`exec("echo", ["hi", 1])`, "{stdout: hi 1\n, stderr: , code: 0}"},
+		{`exec("sh", ["-c", "echo $0 >&2; exit 3"]).code`, "3"},
+		{`exec("sh", ["-c", "echo $0 >&2; exit 3"]).stderr`, "sh\n"},
+		{`exec("cat", [], "from stdin").stdout`, "from stdin"},
+		{`exec("sh", ["-c", "echo $LAIL_VAR"], head([]), ["LAIL_VAR=value"]).stdout`, "value\n"},
+		{`exec("pwd", [], head([]), [], "DIR").stdout.head()`, "/"},
+		{`exec("lail-command-that-does-not-exist")`, "null"},
+		{`try(fn() { exec("cat", [], [1]) }, fn(e) { e.message })`, "Illegal State: exec: stdin must be a string; got Array."},
+		{`try(fn() { exec("pwd", [], head([]), [], 5) }, fn(e) { e.message })`, "Illegal State: exec: dir must be a string; got Integer."},
+		{`try(fn() { exec("env", [], head([]), ["A=1", 2]) }, fn(e) { e.message })`, "Illegal State: exec: env must contain only strings; got 2."},
+		{`try(fn() { exec("env", [], head([]), "A=1") }, fn(e) { e.message })`, "Illegal State: exec: env must be an array of strings; got String."},
+	}
+
+	for _, tt := range tests {
+		got := testEval(strings.Replace(tt.input, "DIR", dir, -1))
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestRegexModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`regex.match("^l[a-z]+$", "lail")`, "true"},
+		{`regex.match("^l[a-z]+$", "Lail")`, "false"},
+		{`regex.find("[0-9]+", "abc 123 45")`, "123"},
+		{`regex.find("[0-9]+", "abc")`, "null"},
+		{`regex.findAll("[0-9]+", "abc 123 45")`, "[123, 45]"},
+		{`regex.replace("a(b*)", "xabbyab", "<$1>")`, "x<bb>y<b>"},
+		{`regex.replace("[0-9]+", "1 and 22", fn(m) { m + m })`, "11 and 2222"},
+		{`regex.split(",\\s*", "a, b,c")`, "[a, b, c]"},
+		{`regex.groups("(?P<key>\\w+)=(\\w+)", "x: name=lail")`, "{0: name=lail, key: name, 2: lail}"},
+		{`regex.groups("(?P<key>\\w+)=(\\w+)", "x: name=lail").key`, "name"},
+		{`regex.groups("(?P<key>\\w+)=", "none")`, "null"},
+		{`let r = regex.compile("b+"); [r.match("abc"), r.find("abbc"), regex.split(r, "abbcbd")]`, "[true, bb, [a, c, d]]"},
+		{`typeof(regex.compile("b+"))`, "Regex"},
+		{`regex.compile("b+")`, "/b+/"},
+		{`regex.compile("(")`, "null"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+// fakeClock starts at a fixed time and only advances when sleeping
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestTimeModule(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`time.now()`, "2020-05-17T10:30:00Z"},
+		{`let t = time.now(); [t.year, t.month, t.day, t.hour, t.minute, t.second, t.weekday]`, "[2020, 5, 17, 10, 30, 0, 0]"},
+		{`time.now().unix()`, "1589711400"},
+		{`time.fromUnix(0)`, "1970-01-01T00:00:00Z"},
+		{`time.parse("17/05/2020", "02/01/2006").format("Jan 2, 2006")`, "May 17, 2020"},
+		{`time.parse("2020-05-17T12:00:00Z") - time.now()`, "1h30m0s"},
+		{`time.now() + time.duration("36h")`, "2020-05-18T22:30:00Z"},
+		{`time.now() - time.duration("30m") < time.now()`, "true"},
+		{`time.duration("1h") * 2 + time.duration("30m")`, "2h30m0s"},
+		{`time.duration("1h30m").minutes`, "90"},
+		{`let start = time.now(); time.sleep(time.duration("2s")); time.sleep(500); time.since(start).millis`, "2500"},
+		{`"now: " + time.now()`, "now: 2020-05-17T10:30:00Z"},
+		{`time.parse("not a time")`, "null"},
+		{`typeof(time.duration("1s"))`, "Duration"},
+	}
+
+	for _, tt := range tests {
+		Settings = DefaultConfig()
+		Settings.Clock = &fakeClock{now: time.Date(2020, time.May, 17, 10, 30, 0, 0, time.UTC)}
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestRandomModule(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+
+	input := `[random.int(1, 6), random.float(), random.float(10, 20), random.choice([1, 2, 3]), random.shuffle([1, 2, 3, 4]), random.sample([1, 2, 3, 4], 2)]`
+	Settings = DefaultConfig()
+	Settings.Random = rand.New(rand.NewSource(42))
+	first := testEval(input).Inspect()
+	Settings.Random = rand.New(rand.NewSource(42))
+	second := testEval(input).Inspect()
+	if first != second {
+		t.Errorf("same seed should give same results. got %s and %s", first, second)
+	}
+
+	for i := 0; i < 20; i++ {
+		n := testEval(`random.int(1, 3)`).(*object.Integer).Value
+		if n < 1 || n > 3 {
+			t.Errorf("random.int(1, 3) out of range. got=%d", n)
+		}
+		f := testEval(`random.float(10, 20)`).(*object.Float).Value
+		if f < 10 || f >= 20 {
+			t.Errorf("random.float(10, 20) out of range. got=%g", f)
+		}
+		sample := testEval(`random.sample([1, 2], 2)`).Inspect()
+		if sample != "[1, 2]" && sample != "[2, 1]" {
+			t.Errorf("random.sample([1, 2], 2) wrong. got=%s", sample)
+		}
+	}
+	testIntegerObject(t, testEval(`random.int(5, 5)`), 5)
+	testIntegerObject(t, testEval(`random.choice([7])`), 7)
+	testIntegerObject(t, testEval(`random.int(9223372036854775807, 9223372036854775807)`), math.MaxInt64)
+
+	// ranges with more than math.MaxInt64 integers
+	bounds := []struct {
+		input    string
+		min, max int64
+	}{
+		{`random.int(0, 9223372036854775807)`, 0, math.MaxInt64},
+		{`random.int(-1, 9223372036854775807)`, -1, math.MaxInt64},
+		{`random.int(-9223372036854775807 - 1, 9223372036854775807)`, math.MinInt64, math.MaxInt64},
+		{`random.int(-9223372036854775807 - 1, 0)`, math.MinInt64, 0},
+	}
+	for _, tt := range bounds {
+		for i := 0; i < 20; i++ {
+			n, ok := testEval(tt.input).(*object.Integer)
+			if !ok || n.Big != nil || n.Value < tt.min || n.Value > tt.max {
+				t.Fatalf("%s: out of range. got=%v", tt.input, testEval(tt.input))
+			}
+		}
+	}
+
+	for _, input := range []string{`random.int(3, 1)`, `random.sample([1], 2)`, `random.choice(1)`} {
+		if got := testEval(input); got != Null {
+			t.Errorf("%s: expected error, got %q", input, got.Inspect())
+		}
+	}
+	if got := testEval(`random.choice([])`); got != Null {
+		t.Errorf("choice of empty array should be null, got %q", got.Inspect())
+	}
+}
+
+func TestFloatArithmetic(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.5", "1.5"},
+		{"2.0", "2.0"},
+		{"-2.5", "-2.5"},
+		{"1.5 + 1", "2.5"},
+		{"1 + 1.5", "2.5"},
+		{"3 / 2.0", "1.5"},
+		{"2.5 * 2", "5.0"},
+		{"2.5 - 0.5", "2.0"},
+		{"2.5 > 2", "true"},
+		{"2.5 <= 2", "false"},
+		{"1.0 == 1", "true"},
+		{`"x" + 1.5`, "x1.5"},
+		{"!0.0", "true"},
+		{"1.0 / 0", "null"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestMathModule(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"math.abs(-3)", "3"},
+		{"math.abs(-2.5)", "2.5"},
+		{"math.min(3, 1.5, 2)", "1.5"},
+		{"math.max([1, 9, 4])", "9"},
+		{"math.floor(2.7)", "2"},
+		{"math.ceil(2.1)", "3"},
+		{"math.round(2.5)", "3"},
+		{"math.sqrt(16)", "4.0"},
+		{"math.pow(2, 10)", "1024"},
+		{"math.pow(2, -1)", "0.5"},
+		{"math.pow(4, 0.5)", "2.0"},
+		{"math.log(math.e)", "1.0"},
+		{"math.log(8, 2)", "3.0"},
+		{"math.cos(0)", "1.0"},
+		{"math.atan2(0, 1)", "0.0"},
+		{"math.gcd(12, -18)", "6"},
+		{"math.clamp(15, 0, 10)", "10"},
+		{"math.clamp(-1.5, 0, 10)", "0"},
+		{"math.clamp(5, 0, 10)", "5"},
+		{"math.pi", "3.141592653589793"},
+		{"16.sqrt()", "4.0"},
+		{"(0 - 4).abs", "4"},
+		{"math.min()", "null"},
+		{`math.sqrt("4")`, "null"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestRoundingNonFiniteFloats(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"math.floor(math.sqrt(-1))", "Illegal State: math.floor: NaN cannot be rounded to an integer."},
+		{"math.round(math.log(0))", "Illegal State: math.round: -Inf cannot be rounded to an integer."},
+		{"math.ceil(0 - math.log(0))", "Illegal State: math.ceil: +Inf cannot be rounded to an integer."},
+	}
+
+	for _, tt := range tests {
+		got := testEval(`try(fn() { ` + tt.input + ` }, fn(e) { e.message })`)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestBigIntegerArguments(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+	Settings = DefaultConfig()
+	Settings.Clock = &fakeClock{now: time.Date(2020, time.May, 17, 10, 30, 0, 0, time.UTC)}
+	exitCode := -1
+	Settings.Exit = func(code int) { exitCode = code }
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"time.fromUnix(99999999999999999999)", "Illegal State: time.fromUnix: 99999999999999999999 is out of range."},
+		{"time.sleep(99999999999999999999)", "Illegal State: time.sleep: 99999999999999999999 milliseconds is out of range."},
+		{"time.sleep(9223372036854775807)", "Illegal State: time.sleep: 9223372036854775807 milliseconds is out of range."},
+		{"time.sleep(0 - 9223372036854775807)", "Illegal State: time.sleep: -9223372036854775807 milliseconds is out of range."},
+		{"time.sleep(0 - 1)", "Illegal State: time.sleep: -1 milliseconds is out of range."},
+		{"exit(99999999999999999999)", "Illegal State: exit: 99999999999999999999 is out of range."},
+		{`time.duration("1s") * 99999999999999999999`, "Arithmetic Error: Duration overflow: result does not fit in 64 bits of nanoseconds."},
+		{`time.duration("1s") * 9999999999999`, "Arithmetic Error: Duration overflow: result does not fit in 64 bits of nanoseconds."},
+		{`time.duration("1s") / 99999999999999999999`, "0s"},
+		{`time.duration("1s") / 0`, "Arithmetic Error: Division by zero."},
+		{"random.sample([1, 2], 99999999999999999999)", "Illegal State: random.sample: 99999999999999999999 is out of range."},
+		{"random.int(99999999999999999999, 1)", "Illegal State: random.int: empty range [99999999999999999999, 1]."},
+		{"random.int(99999999999999999999, 99999999999999999999)", "99999999999999999999"},
+		{"let n = random.int(1, 99999999999999999999); [n >= 1, n <= 99999999999999999999]", "[true, true]"},
+		{"let n = random.int(-99999999999999999999, -9223372036854775809); n <= -9223372036854775809", "true"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(`try(fn() { ` + tt.input + ` }, fn(e) { e.message })`)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+	if exitCode != -1 {
+		t.Errorf("exit with a big integer should not exit. got code=%d", exitCode)
+	}
+}
+
+func TestBigIntegers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`import "./test/fact.code" fact(20)`, "2432902008176640000"},
+		{`import "./test/fact.code" fact(25)`, "15511210043330985984000000"},
+		{`import "./test/fact.code" fact(30) / fact(28)`, "870"},
+		{"9223372036854775807 + 1", "9223372036854775808"},
+		{"9223372036854775807 + 1 - 1", "9223372036854775807"},
+		{"-9223372036854775807 - 2", "-9223372036854775809"},
+		{"4294967296 * 4294967296", "18446744073709551616"},
+		{"-(-9223372036854775807 - 1)", "9223372036854775808"},
+		{"(-9223372036854775807 - 1) / -1", "9223372036854775808"},
+		{"99999999999999999999", "99999999999999999999"},
+		{"99999999999999999999 > 9223372036854775807", "true"},
+		{"-99999999999999999999 < 1", "true"},
+		{"99999999999999999999 == 99999999999999999999", "true"},
+		{"99999999999999999999 / 0", "null"},
+		{"!99999999999999999999", "false"},
+		{"typeof(99999999999999999999)", "Integer"},
+		{"math.pow(2, 100)", "1267650600228229401496703205376"},
+		{"math.abs(-99999999999999999999)", "99999999999999999999"},
+		{"99999999999999999999 * 0.5", "5e+19"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+
+	// results that fit are demoted back to int64
+	res := testEval("9223372036854775807 + 1 - 1").(*object.Integer)
+	if res.Big != nil || res.Value != 9223372036854775807 {
+		t.Errorf("result should be demoted to int64. got=%+v", res)
+	}
+}
+
+func TestDecimals(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"12.34d", "12.34"},
+		{"0.05d", "0.05"},
+		{"-1.50d", "-1.50"},
+		{"0.1d + 0.2d", "0.3"},
+		{"0.1d + 0.2d == 0.3d", "true"},
+		{"1.10d - 0.1d", "1.00"},
+		{"12.34d * 3", "37.02"},
+		{"10.00d / 3", "3.3333333333333333"},
+		{"2d / 3", "0.6666666666666667"},
+		{"1d / 8", "0.125"},
+		{"10.00d / 2", "5.00"},
+		{"1d / 0", "null"},
+		{"3.5d > 3", "true"},
+		{"3.5d <= 3.50d", "true"},
+		{`"total: " + 4.10d`, "total: 4.10"},
+		{"!0.00d", "true"},
+		{"1.5d + 1.5", "null"},
+		{"typeof(1d)", "Decimal"},
+		{`decimal.of("19.99") * 2`, "39.98"},
+		{`decimal.of(" -3 ")`, "-3"},
+		{`decimal.of(99999999999999999999) + 0.5d`, "99999999999999999999.5"},
+		{`decimal.of("abc")`, "null"},
+		{`decimal.of(1.5)`, "null"},
+		{"7.99d.toInteger()", "7"},
+		{"(0 - 7.99d).toInteger()", "-7"},
+		{"4.10d.toString()", "4.10"},
+		{"2.345d.round(2)", "2.34"},
+		{"2.355d.round(2)", "2.36"},
+		{`2.345d.round(2, "halfUp")`, "2.35"},
+		{`2.345d.round(2, "halfDown")`, "2.34"},
+		{`2.341d.round(2, "up")`, "2.35"},
+		{`2.349d.round(2, "down")`, "2.34"},
+		{`(0 - 2.341d).round(2, "ceiling")`, "-2.34"},
+		{`(0 - 2.341d).round(2, "floor")`, "-2.35"},
+		{`2.5d.round(0, "sideways")`, "null"},
+		{`decimal.setPrecision(2); 2d / 3`, "0.67"},
+		{`decimal.setPrecision(2); decimal.setRounding("down"); 2d / 3`, "0.66"},
+		{`try(fn() { decimal.setPrecision(4294967298) }, fn(e) { e.message })`, "Illegal State: decimal.setPrecision: 4294967298 is not a number of decimal places from 0 to 10000."},
+		{`try(fn() { decimal.setPrecision(0 - 1) }, fn(e) { e.message })`, "Illegal State: decimal.setPrecision: -1 is not a number of decimal places from 0 to 10000."},
+		{`try(fn() { decimal.setPrecision(99999999999999999999) }, fn(e) { e.kind })`, "Error"},
+		{`decimal.setPrecision(4294967298); 2d / 3`, "0.6666666666666667"},
+		{`try(fn() { 2.5d.round(2147483648) }, fn(e) { e.message })`, "Illegal State: decimal.round: 2147483648 is not a number of decimal places from 0 to 10000."},
+		{`2.5d.round(10000)`, "2.5"},
+	}
+
+	for _, tt := range tests {
+		Settings = DefaultConfig()
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+
+	Settings = DefaultConfig()
+	Settings.Decimal = DecimalContext{Precision: 4, Rounding: Up}
+	if got := testEval("1d / 3").Inspect(); got != "0.3334" {
+		t.Errorf("configured precision and rounding not used. got %q", got)
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`1 + 2`, "3"},
+		{`1 + 1.5`, "2.5"},
+		{`1 + 1.5d`, "2.5"},
+		{`"a" + "b"`, "ab"},
+		{`"abc" - "b"`, "abc"},
+		{`[1] + [2]`, "[1, 2]"},
+		{`2 > 1`, "true"},
+		{`1 == 1`, "true"},
+		{`1 == "1"`, "false"},
+		{`1 != "1"`, "true"},
+		{`1 == 1.0`, "true"},
+		{`[1, 2] == "[1, 2]"`, "false"},
+		{`time.duration("1s") * 2`, "2s"},
+		{`"a" * 3`, "null"},
+		{`true + true`, "null"},
+		{`"a" + 1`, "null"},
+		{`1 + "a"`, "null"},
+		{`1.5 + 1.5d`, "null"},
+		{`"b" > "a"`, "null"},
+		{`[1] - [1]`, "null"},
+		{`"x" + time.duration("1s")`, "null"},
+	}
+
+	for _, tt := range tests {
+		Settings = DefaultConfig()
+		Settings.Strict = true
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+
+	Settings = DefaultConfig()
+	pragmaTests := []struct {
+		input    string
+		expected string
+	}{
+		{`"use strict"; 1 == "1"`, "false"},
+		{`"use strict"; "a" * 3`, "null"},
+		{`1 == "1"`, "true"},
+		{`"a" * 3`, "0"},
+	}
+	for _, tt := range pragmaTests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+	if Settings.Strict {
+		t.Errorf("strict pragma should not outlive its program")
+	}
+
+	// the pragma applies to the operators of its own program, wherever its functions are called from
+	importTests := []struct {
+		input    string
+		expected string
+	}{
+		{`import "./test/strict.code" strictAdd(1, 2)`, "3"},
+		{`import "./test/strict.code" strictAdd(1, "a")`, "null"},
+		{`import "./test/strict.code" let x = strictAdd(1, 2); 1 + "a"`, "1a"},
+		{`"use strict"; import "./test/loose.code" looseAdd(1, "a")`, "1a"},
+		{`"use strict"; import "./test/loose.code" let x = looseAdd(1, "a"); 1 + "a"`, "null"},
+		{`"use strict"; "${1 + "a"}"`, "null"},
+	}
+	for _, tt := range importTests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestEquality(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`[1, 2] == [1, 2]`, true},
+		{`[1, [2, "a"]] == [1, [2, "a"]]`, true},
+		{`[1, 2] == [2, 1]`, false},
+		{`[1, 2] == [1, 2, 3]`, false},
+		{`[1, 2] == "[1, 2]"`, false},
+		{`[1, 2] != "[1, 2]"`, true},
+		{`[] == []`, true},
+		{`"1" == 1`, true},
+		{`"true" == true`, true},
+		{`"null" == head([])`, true},
+		{`"2.5" == 2.5d`, true},
+		{`"1" != 1`, false},
+		{`"01" == 1`, false},
+		{`"[1]" == [1]`, false},
+		{`["1"] == [1]`, false},
+		{`"use strict"; "1" == 1`, false},
+		{`"use strict"; "1" != 1`, true},
+		{`"use strict"; "true" == true`, false},
+		{`head([]) == head([])`, true},
+		{`true == 1`, false},
+		{`1 == 1.0`, true},
+		{`1.5 == 1.5`, true},
+		{`1.0d == 1.00d`, true},
+		{`[1.0d] == [1.00d]`, true},
+		{`9223372036854775807 + 1 == 9223372036854775808`, true},
+		{`time.duration("1s") == time.duration("1000ms")`, true},
+		{`let f = fn(x) { x }; f == f`, true},
+		{`fn(x) { x } == fn(x) { x }`, false},
+		{`let f = fn(x) { x }; [f] == [f]`, true},
+		{`out == out`, true},
+		{`out == head`, false},
+	}
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		if !testBooleanObject(t, evaluated, tt.expected) {
+			t.Errorf("input: %s", tt.input)
+		}
+	}
+}
+
+func TestNestedEquality(t *testing.T) {
+	// values nested in arrays and hashes are compared as with ==
+	tests := []struct {
+		lhs      string
+		rhs      string
+		expected bool
+	}{
+		{`1`, `1.0`, true},
+		{`1`, `1.00d`, true},
+		{`9223372036854775808`, `9223372036854775808.0d`, true},
+		{`1.5`, `1.5d`, false},
+		{`0.0`, `-0.0`, true},
+		{`1`, `true`, false},
+		{`[1, [2, "a"]]`, `[1.0, [2.00d, "a"]]`, true},
+	}
+	for _, tt := range tests {
+		top := testEval(tt.lhs + " == " + tt.rhs)
+		if !testBooleanObject(t, top, tt.expected) {
+			t.Errorf("%s == %s", tt.lhs, tt.rhs)
+		}
+		nested := testEval("[" + tt.lhs + "] == [" + tt.rhs + "]")
+		if !testBooleanObject(t, nested, tt.expected) {
+			t.Errorf("[%s] == [%s]", tt.lhs, tt.rhs)
+		}
+	}
+
+	lhs, rhs := object.NewHash(), object.NewHash()
+	lhs.Set("a", &object.Integer{Value: 1})
+	lhs.Set("b", testEval(`[1, 2]`))
+	rhs.Set("b", testEval(`[1.0, 2]`))
+	rhs.Set("a", &object.Decimal{Unscaled: big.NewInt(10), Scale: 1})
+	if !lhs.Equals(rhs) {
+		t.Errorf("hashes with equal pairs should be equal regardless of key order")
+	}
+	rhs.Set("a", &object.Integer{Value: 2})
+	if lhs.Equals(rhs) {
+		t.Errorf("hashes with different values should not be equal")
+	}
+}
+
+func TestHashKey(t *testing.T) {
+	tests := []struct {
+		lhs string
+		rhs string
+	}{
+		{`[1, [2, "a"]]`, `[1, [2, "a"]]`},
+		{`"str"`, `"str"`},
+		{`1`, `1.0`},
+		{`1`, `1d`},
+		{`1.00d`, `1.0d`},
+		{`100`, `100.0d`},
+		{`1.5d`, `1.50d`},
+		{`0.0`, `-0.0`},
+		{`0`, `-0.0d`},
+		{`9223372036854775807 + 1`, `9223372036854775808`},
+		{`9223372036854775808`, `9223372036854775808.0d`},
+		{`9007199254740993`, `9007199254740992.0`},
+		{`[1, [2, "a"]]`, `[1.0, [2.00d, "a"]]`},
+		{`null`, `null`},
+	}
+	for _, tt := range tests {
+		lhs, rhs := testEval(tt.lhs), testEval(tt.rhs)
+		if !lhs.Equals(rhs) || !rhs.Equals(lhs) {
+			t.Errorf("%s should equal %s", tt.lhs, tt.rhs)
+		}
+		if lhs.HashKey() != rhs.HashKey() {
+			t.Errorf("%s and %s are equal but hash differently", tt.lhs, tt.rhs)
+		}
+	}
+
+	lhs, rhs := object.NewHash(), object.NewHash()
+	lhs.Set("a", &object.Integer{Value: 1})
+	lhs.Set("b", testEval(`[1, 2]`))
+	rhs.Set("b", testEval(`[1.0, 2]`))
+	rhs.Set("a", &object.Decimal{Unscaled: big.NewInt(10), Scale: 1})
+	if lhs.HashKey() != rhs.HashKey() {
+		t.Errorf("equal hashes should hash equally regardless of key order")
+	}
+	huge := &object.Decimal{Unscaled: big.NewInt(1), Scale: -400}
+	if huge.HashKey() != (&object.Float{Value: math.Inf(1)}).HashKey() {
+		t.Errorf("a decimal beyond the largest float should hash like +Inf")
+	}
+}
+
+func TestCheckedArithmetic(t *testing.T) {
+	defer func(previous *Config) { Settings = previous }(Settings)
+
+	tests := []struct {
+		mode     ArithmeticMode
+		input    string
+		expected string
+	}{
+		{Promote, `try(fn() { 1 / 0 }, fn(e) { e.kind })`, "ArithmeticError"},
+		{Promote, `try(fn() { 1.5 / 0 }, fn(e) { e.message })`, "Arithmetic Error: Division by zero."},
+		{Promote, `try(fn() { 1d / 0 }, fn(e) { e.message })`, "Arithmetic Error: Division by zero."},
+		{Promote, `try(fn() { time.duration("1s") / 0 }, fn(e) { e.kind })`, "ArithmeticError"},
+		{Promote, "try(fn() {\n  let x = 2;\n  x / 0\n}, fn(e) { [e.line, e.column] })", "[3, 5]"},
+		{Promote, `try(fn() { 1 / 0 })`, "null"},
+		{Promote, `try(fn() { 4 / 2 }, fn(e) { e })`, "2"},
+		{Promote, `try(fn() { let x = 1 / 0; "unreachable" }, fn(e) { "caught" })`, "caught"},
+		{Promote, `try(fn() { unknown }, fn(e) { e.kind })`, "Error"},
+		{Promote, `try(fn() { try(fn() { 1 / 0 }, fn(e) { 1 / 0 }) }, fn(e) { "outer" })`, "outer"},
+		{Promote, `9223372036854775807 + 1`, "9223372036854775808"},
+		{Checked, `try(fn() { 9223372036854775807 + 1 }, fn(e) { e.message })`, "Arithmetic Error: Integer overflow: result of + does not fit in 64 bits."},
+		{Checked, `try(fn() { -9223372036854775807 - 2 }, fn(e) { e.kind })`, "ArithmeticError"},
+		{Checked, `try(fn() { 4294967296 * 4294967296 }, fn(e) { e.kind })`, "ArithmeticError"},
+		{Checked, `try(fn() { 1 / 0 }, fn(e) { e.kind })`, "ArithmeticError"},
+		{Checked, `9223372036854775806 + 1`, "9223372036854775807"},
+		{Legacy, `9223372036854775807 + 1`, "-9223372036854775808"},
+		{Legacy, `try(fn() { 1 / 0 }, fn(e) { "caught" })`, "null"},
+		{Legacy, `1.5 / 0`, "null"},
+		{Promote, `math.pow(2, 100)`, "1267650600228229401496703205376"},
+		{Promote, `math.pow(-2, 63)`, "-9223372036854775808"},
+		{Promote, `math.pow(-1, 9223372036854775807)`, "-1"},
+		{Promote, `math.pow(1, 99999999999999999999)`, "1"},
+		{Promote, `try(fn() { math.pow(3, 100000000) }, fn(e) { e.message })`, "Arithmetic Error: Integer overflow: result of math.pow exceeds 1048576 bits."},
+		{Promote, `try(fn() { math.pow(2, 99999999999999999999) }, fn(e) { e.kind })`, "ArithmeticError"},
+		{Checked, `try(fn() { math.pow(2, 100) }, fn(e) { e.message })`, "Arithmetic Error: Integer overflow: result of math.pow does not fit in 64 bits."},
+		{Checked, `try(fn() { math.pow(3, 100000000) }, fn(e) { e.kind })`, "ArithmeticError"},
+		{Checked, `math.pow(2, 62)`, "4611686018427387904"},
+		{Checked, `math.pow(-2, 63)`, "-9223372036854775808"},
+		{Legacy, `math.pow(2, 64)`, "0"},
+		{Legacy, `math.pow(3, 41)`, "-420491770248316829"},
+		{Legacy, `math.pow(3, 100000000)`, "5478338451055735809"},
+	}
+
+	for _, tt := range tests {
+		Settings = DefaultConfig()
+		Settings.Arithmetic = tt.mode
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s (%s): got %q want %q", tt.input, tt.mode, got.Inspect(), tt.expected)
+		}
+	}
+
+	Settings = DefaultConfig()
+	errors := ErrorCount()
+	testEval(`1 / 0`)
+	if ErrorCount() != errors+1 {
+		t.Errorf("division by zero outside of try should be reported")
+	}
+}
+
+func TestNullSafeOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`null`, "null"},
+		{`typeof(null)`, "Null"},
+		{`null == null`, "true"},
+		{`head([]) == null`, "true"},
+		{`0 == null`, "false"},
+		{`null ?? 5`, "5"},
+		{`3 ?? 5`, "3"},
+		{`false ?? 5`, "false"},
+		{`null ?? null ?? "c"`, "c"},
+		{`let x = null; x ?? [1]`, "[1]"},
+		{`let called = false; 1 ?? (called = true); called`, "false"},
+		{`let x = null; x?.head()`, "null"},
+		{`let x = [1, 2]; x?.head()`, "1"},
+		{`let x = null; x?.stdout`, "null"},
+		{`null?.time.format()`, "null"},
+		{`let called = false; null?.tail(called = true); called`, "false"},
+		{`let d = time.duration("2s"); d?.seconds`, "2"},
+		{`let x = null; x?.head() ?? "empty"`, "empty"},
+		{`let x = null; x?.a.b.c`, "null"},
+		{`let x = null; x?.head().tail().head()`, "null"},
+		{`let x = null; x?.time.format("15:04") ?? "never"`, "never"},
+		{`let called = false; null?.a.tail(called = true); called`, "false"},
+	}
+
+	for _, tt := range tests {
+		errors := ErrorCount()
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+		if ErrorCount() != errors {
+			t.Errorf("%s: raised an error", tt.input)
+		}
+	}
+
+	// only ?. on null short-circuits, a null produced later in the chain does not
+	errors := ErrorCount()
+	testEval(`let x = [null]; x?.head().time`)
+	if ErrorCount() == errors {
+		t.Errorf("member access on a null head should raise an error")
+	}
+}
+
+func TestConversions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`int("42")`, "42"},
+		{`int("-42")`, "-42"},
+		{`int("ff", 16)`, "255"},
+		{`int("-101", 2)`, "-5"},
+		{`int("zz", 36)`, "1295"},
+		{`int("99999999999999999999")`, "99999999999999999999"},
+		{`int(7)`, "7"},
+		{`int(3.9)`, "3"},
+		{`int(-3.9)`, "-3"},
+		{`int(float("1e20"))`, "100000000000000000000"},
+		{`int(-2.75d)`, "-2"},
+		{`int(true)`, "1"},
+		{`typeof(int("1"))`, "Integer"},
+		{`float("1.5")`, "1.5"},
+		{`float("-2e3")`, "-2000.0"},
+		{`float(2)`, "2.0"},
+		{`float(1.25d)`, "1.25"},
+		{`float(false)`, "0.0"},
+		{`str(42)`, "42"},
+		{`str("a") + str(1.5)`, "a1.5"},
+		{`str([1, "a"])`, "[1, a]"},
+		{`typeof(str(null))`, "String"},
+		{`bool("true")`, "true"},
+		{`bool("false")`, "false"},
+		{`bool(0)`, "false"},
+		{`bool(0.5)`, "true"},
+		{`bool(null)`, "false"},
+		{`repr("a")`, `"a"`},
+		{`repr("say \"hi\"\n")`, `"say \"hi\"\n"`},
+		{`repr([1, "a", [null, true]])`, `[1, "a", [null, true]]`},
+		{`repr(1.0)`, "1.0"},
+		{`int(" 42")`, "null"},
+		{`int("4_2")`, "null"},
+		{`int("")`, "null"},
+		{`int("0x10")`, "null"},
+		{`int("12", 1)`, "null"},
+		{`int("12", 37)`, "null"},
+		{`int(12, 10)`, "null"},
+		{`int("12", "10")`, "null"},
+		{`int([1])`, "null"},
+		{`float("1.5.2")`, "null"},
+		{`float("")`, "null"},
+		{`float(null)`, "null"},
+		{`bool("True")`, "null"},
+		{`bool("1")`, "null"},
+		{`bool([])`, "null"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestNonFiniteConversions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`int(math.sqrt(-1))`, "Illegal State: int: NaN cannot be converted to an integer."},
+		{`int(math.log(0))`, "Illegal State: int: -Inf cannot be converted to an integer."},
+		{`int(0 - math.log(0))`, "Illegal State: int: +Inf cannot be converted to an integer."},
+		{`float("nan")`, `Illegal State: float: "nan" is not a valid float.`},
+		{`float("NaN")`, `Illegal State: float: "NaN" is not a valid float.`},
+		{`float("inf")`, `Illegal State: float: "inf" is not a valid float.`},
+		{`float("+Inf")`, `Illegal State: float: "+Inf" is not a valid float.`},
+		{`float("-inf")`, `Illegal State: float: "-inf" is not a valid float.`},
+		{`float("infinity")`, `Illegal State: float: "infinity" is not a valid float.`},
+		{`float("-Infinity")`, `Illegal State: float: "-Infinity" is not a valid float.`},
+		{`float("1e400")`, `Illegal State: float: "1e400" is not a valid float.`},
+	}
+
+	for _, tt := range tests {
+		got := testEval(`try(fn() { ` + tt.input + ` }, fn(e) { e.message })`)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestRepr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`repr(["a, b", "c"])`, `["a, b", "c"]`},
+		{`repr(["a", "b", "c"])`, `["a", "b", "c"]`},
+		{`repr("tab\there")`, `"tab\there"`},
+		{`repr(1.5d)`, "1.5d"},
+		{`repr([[]])`, "[[]]"},
+		{`repr(regex.groups("(?P<x>a)", "a"))`, `{"0": "a", "x": "a"}`},
+		{`pretty([1, [2, 3]])`, "[1, [2, 3]]"},
+		{`pretty([], 1)`, "[]"},
+		{`pretty([1, [2, 3]], 10)`, "[\n  1,\n  [2, 3]\n]"},
+		{`pretty([1, [2, 3]], 6)`, "[\n  1,\n  [\n    2,\n    3\n  ]\n]"},
+		{`pretty(["abcdefgh"], 4)`, "[\n  \"abcdefgh\"\n]"},
+		{`pretty(regex.groups("(?P<x>a)(?P<y>b)", "ab"), 20)`, "{\n  \"0\": \"ab\",\n  \"x\": \"a\",\n  \"y\": \"b\"\n}"},
+		{`pretty("a", 0)`, "null"},
+		{`pretty([[1, 2], [3, 4]], 8)`, "[\n  [\n    1,\n    2\n  ],\n  [3, 4]\n]"},
+		{`pretty([[1, 2], [3, 4]], 9)`, "[\n  [1, 2],\n  [3, 4]\n]"},
+		{`repr("\u{200B}")`, `"\u{200b}"`},
+		{`repr("\x01\x7F")`, `"\x01\x7f"`},
+		{`repr("café 🌙")`, `"café 🌙"`},
+		{`repr("\${x} costs $5")`, `"\${x} costs $5"`},
+		{`repr("back\\slash \"quoted\"\r\n")`, `"back\\slash \"quoted\"\r\n"`},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+
+	// the representation of a string reads back as the same string
+	for _, input := range []string{`"\${x}"`, `"\u{200B}\x00"`, `"a\\$b\t{}"`, `"\u{10FFFF}"`} {
+		str := testEval(input)
+		if got := testEval(object.Repr(str)); !got.Equals(str) {
+			t.Errorf("%s: %s reads back as %q", input, object.Repr(str), got.Inspect())
+		}
+	}
+}
+
+func TestInterpolatedStrings(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let x = 3; "x = ${x}"`, "x = 3"},
+		{`let x = 3; "${x}${x}"`, "33"},
+		{`let x = 3; "${x + 1} > ${x}"`, "4 > 3"},
+		{`let xs = [1, "a"]; "xs = ${xs}"`, "xs = [1, a]"},
+		{`"${"inner ${1 + 1}"}"`, "inner 2"},
+		{`"${null ?? "default"}"`, "default"},
+		{`"${fn(a) { a * 2 }(4)}"`, "8"},
+		{`"\${x}"`, "${x}"},
+		{`"cost: $5"`, "cost: $5"},
+		{`typeof("${1}")`, "String"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestLocalizedPrograms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[٣, ١٢٣ + 1, ۴۵, ٣.١٤, ١_٠٠٠, ٢.٥d]", "[3, 124, 45, 3.14, 1000, 2.5]"},
+		{"// keywords: ar\nليكن ضعف = دالة(س) { أرجع س * ٢ }; ضعف(٢١)", "42"},
+		{"// keywords: ar\nإذا (خطأ) { ١ } وإلا { عدم ?? ٢ }", "2"},
+		{"// keywords: ar\n\"${ إذا (صحيح) { \"نعم\" } }\"", "نعم"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b] = [1, 2]; a + b", "3"},
+		{"let [a, b, ...rest] = [1, 2, 3, 4]; rest", "[3, 4]"},
+		{"let [a, ...rest] = [1]; rest", "[]"},
+		{"let [a, [b, c]] = [1, [2, 3]]; [c, b, a]", "[3, 2, 1]"},
+		{"let [] = []; 1", "1"},
+		{"let e = try(fn() { 1 / 0 }, fn(e) { e }); let {kind, message} = e; kind", "ArithmeticError"},
+		{"let f = fn([x, y], z) { x * y + z }; f([2, 3], 4)", "10"},
+		{"let e = try(fn() { 1 / 0 }, fn(e) { e }); fn({kind}) { kind }(e)", "ArithmeticError"},
+		{"let xs = [1, 2, 3]; let [a, ...rest] = xs; let [b] = rest; xs", "[1, 2, 3]"},
+		// nothing is bound when the value does not match
+		{`let kind = "old"; let e = try(fn() { 1 / 0 }, fn(e) { e }); let {kind, nope} = e; kind`, "old"},
+		{"let a = 0; let [a, [b]] = [1, 2]; a", "0"},
+		{"let rest = 0; let [[a], ...rest] = [1, 2]; rest", "0"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(tt.input)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestDestructuringErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b] = [1];", "Illegal State: [a, b] expected 2 element(s); got 1."},
+		{"let [a] = [1, 2];", "Illegal State: [a] expected 1 element(s); got 2."},
+		{"let [a, b, ...rest] = [1];", "Illegal State: [a, b, ...rest] expected at least 2 element(s); got 1."},
+		{"let [a] = 1;", `Illegal State: Cannot destructure Integer as [a].`},
+		{"let {a} = [1];", `Illegal State: Cannot destructure Array as {a}.`},
+		{`let {name} = try(fn() { 1 / 0 }, fn(e) { e });`, `Illegal State: {name} expected key "name".`},
+		{"let [a, [b]] = [1, 2];", `Illegal State: Cannot destructure Integer as [b].`},
+		{"fn([a, b]) { a }([1, 2, 3])", "Illegal State: [a, b] expected 2 element(s); got 3."},
+	}
+
+	for _, tt := range tests {
+		got := testEval(`try(fn() { ` + tt.input + ` }, fn(e) { e.message })`)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestFunctionParameterKinds(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`let greet = fn(name, greeting = "Hello") { greeting + ", " + name }; greet("you")`, "Hello, you"},
+		{`let greet = fn(name, greeting = "Hello") { greeting + ", " + name }; greet("you", "Hi")`, "Hi, you"},
+		{"let f = fn(x, y = x * 2) { [x, y] }; f(3)", "[3, 6]"},
+		{"let f = fn() { 1 }; let g = fn(x = f()) { x }; let f = fn() { 2 }; g()", "2"},
+		{"let f = fn(first, ...rest) { [first, rest] }; f(1)", "[1, []]"},
+		{"let f = fn(first, ...rest) { [first, rest] }; f(1, 2, 3)", "[1, [2, 3]]"},
+		{"let f = fn(...all) { all }; f()", "[]"},
+		{"let f = fn(x, y) { x - y }; f(y: 1, x: 10)", "9"},
+		{"let f = fn(x, y = 2, z = 3) { [x, y, z] }; f(1, z: 4)", "[1, 2, 4]"},
+		{"let f = fn(x = 1, ...rest) { [x, rest] }; f(rest: 5)", "Illegal State: f: function has no parameter rest."},
+		{"let f = fn(x, y = 2) { x + y }; f", "fn(x, y = 2) {\n(x + y)\n}"},
+	}
+
+	for _, tt := range tests {
+		got := testEval(`try(fn() { ` + tt.input + ` }, fn(e) { e.message })`)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestFunctionArgumentErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let f = fn(x, y = 2) { x }; f()", "Illegal State: f: missing argument for parameter x."},
+		{"let f = fn(x, y = 2) { x }; f(1, 2, 3)", "Illegal State: f: function call expected 2 parameter(s); got 3 argument(s)."},
+		{"let f = fn(x) { x }; f(1, x: 2)", "Illegal State: f: parameter x got more than one argument."},
+		{"let f = fn(x) { x }; f(y: 2)", "Illegal State: f: function has no parameter y."},
+		{"fn([a]) { a }(a: [1])", "Illegal State: Anonymous function: function has no parameter a."},
+		{"typeof(x: [1])", "Illegal State: Builtin functions do not accept named arguments; got x."},
+	}
+
+	for _, tt := range tests {
+		got := testEval(`try(fn() { ` + tt.input + ` }, fn(e) { e.message })`)
+		if got.Inspect() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, got.Inspect(), tt.expected)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package lexer
 
 import (
 	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
@@ -16,17 +19,61 @@ type Lexer struct {
 	ch      rune // char to examine
 	line    int  // current line
 	col     int  // current col
+
+	errors []string
+
+	keywords token.Keywords // localized keywords selected by the input
+	started  bool           // whether a token was read, after which keywords cannot be selected
+
+	doc []string // lines of the /// comments since the last token
 }
 
 // New instantiates a new Lexer
 func New(input string) *Lexer {
-	l := &Lexer{input: input, line: 1, col: 1}
+	l := &Lexer{input: input, line: 1, col: 0}
 	l.readChar()
 	return l
 }
 
+// NewAt instantiates a Lexer for input which follows the character at line and col of a larger source,
+// such as an expression embedded in a string, so that its tokens and errors are located in that source
+func NewAt(input string, line, col int) *Lexer {
+	l := &Lexer{input: input, line: line, col: col}
+	l.readChar()
+	return l
+}
+
+// Keywords returns the localized keywords selected by the input, or nil if none were
+func (l *Lexer) Keywords() token.Keywords {
+	return l.keywords
+}
+
+// SetKeywords selects localized keywords, e.g. for an expression embedded in a string of an input which selected them
+func (l *Lexer) SetKeywords(keywords token.Keywords) {
+	l.keywords = keywords
+}
+
+// Errors returns the errors encountered so far, such as invalid escape sequences
+func (l *Lexer) Errors() []string {
+	return l.errors
+}
+
+func (l *Lexer) addError(line, col int, msg string) {
+	l.errors = append(l.errors, fmt.Sprintf("Lexing error: At (%d:%d) %s", line, col, msg))
+}
+
 // NextToken returns next token for the Lexer's input
 func (l *Lexer) NextToken() token.Token {
+	tok := l.nextToken()
+	l.started = true
+	if l.doc != nil {
+		tok.Doc = strings.Join(l.doc, "\n")
+		l.doc = nil
+	}
+	return tok
+}
+
+func (l *Lexer) nextToken() token.Token {
 	var tok token.Token
 Start:
 	l.skipWhiteSpace()
@@ -41,6 +88,8 @@ Start:
 		}
 	case ';':
 		tok = newChToken(token.Semicolon, l.ch, l.line, l.col)
+	case ':':
+		tok = newChToken(token.Colon, l.ch, l.line, l.col)
 	case '(':
 		tok = newChToken(token.Lparen, l.ch, l.line, l.col)
 	case ')':
@@ -67,9 +116,20 @@ Start:
 		}
 	case '/':
 		if l.peekChar() == '/' {
+			line, col := l.line, l.col
 			l.readChar()
+			pos := l.readPos
 			l.skipLine()
-			// No need to create a token because parser doesn't need to know about comments
+			comment := l.input[pos:l.pos]
+			if isDocComment(comment) {
+				l.doc = append(l.doc, strings.TrimPrefix(strings.TrimRight(comment[1:], "\r"), " "))
+			} else if !l.started {
+				l.readDirective(comment, line, col)
+			}
+			// No need to create a token because parser doesn't need to know about comments, doc comments are attached to the next token
+			goto Start
+		} else if l.peekChar() == '*' {
+			l.skipBlockComment()
 			goto Start
 		} else {
 			tok = newChToken(token.Slash, l.ch, l.line, l.col)
@@ -95,12 +155,43 @@ Start:
 	case ',':
 		tok = newChToken(token.Comma, l.ch, l.line, l.col)
 	case '.':
-		tok = newChToken(token.Dot, l.ch, l.line, l.col)
+		if l.peekChar() == '.' && l.peekCharAt(1) == '.' {
+			l.readChar()
+			l.readChar()
+			tok = token.Token{Type: token.Ellipsis, Literal: "...", Line: l.line, Col: l.col - 2}
+		} else {
+			tok = newChToken(token.Dot, l.ch, l.line, l.col)
+		}
+	case '?':
+		switch l.peekChar() {
+		case '?':
+			l.readChar()
+			tok = token.Token{Type: token.Coalesce, Literal: "??", Line: l.line, Col: l.col - 1}
+		case '.':
+			l.readChar()
+			tok = token.Token{Type: token.OptionalDot, Literal: "?.", Line: l.line, Col: l.col - 1}
+		default:
+			l.addError(l.line, l.col, fmt.Sprintf("unexpected character '%s'", printable(l.ch)))
+			tok = newChToken(token.Illegal, l.ch, l.line, l.col)
+		}
+	case '`':
+		tok.Type = token.String
+		tok.Line = l.line
+		tok.Col = l.col
+		tok.Literal = l.readRawString()
+		return tok
 	case '"':
 		tok.Type = token.String
 		tok.Line = l.line
 		tok.Col = l.col
-		tok.Literal = l.readString()
+		if l.peekChar() == '"' && l.peekCharAt(1) == '"' {
+			tok.Literal, tok.Segments = l.readTripleQuotedString()
+		} else {
+			tok.Literal, tok.Segments = l.readString()
+		}
+		if tok.Segments != nil {
+			tok.Type = token.InterpolatedString
+		}
 		return tok
 	case 0:
 		tok.Literal = ""
@@ -112,21 +203,45 @@ Start:
 			tok.Line = l.line
 			tok.Col = l.col
 			tok.Literal = l.readIdentifier()
-			tok.Type = token.LookupIdent(tok.Literal)
+			tok.Type = token.LookupIdent(tok.Literal, l.keywords)
+			return tok
+		} else if _, ok := numeralZero(l.ch); ok {
+			tok.Line = l.line
+			tok.Col = l.col
+			tok.Type, tok.Literal = l.readNumber()
 			return tok
-		} else if isDigit(l.ch) {
+		} else if unicode.IsDigit(l.ch) {
 			tok.Line = l.line
 			tok.Col = l.col
-			tok.Type = token.Int
-			tok.Literal = l.readNumber()
+			tok.Type = token.Illegal
+			tok.Literal = l.readUnsupportedDigits()
 			return tok
 		}
+		l.addError(l.line, l.col, fmt.Sprintf("unexpected character '%s'", printable(l.ch)))
 		tok = newChToken(token.Illegal, l.ch, l.line, l.col)
 	}
 	l.readChar()
 	return tok
 }
 
+// keywordsDirective selects localized keywords in a comment before the first token
+const keywordsDirective = "keywords:"
+
+// readDirective selects the localized keywords named by a comment such as // keywords: ar
+func (l *Lexer) readDirective(comment string, line, col int) {
+	comment = strings.TrimSpace(comment)
+	if !strings.HasPrefix(comment, keywordsDirective) {
+		return
+	}
+	name := strings.TrimSpace(strings.TrimPrefix(comment, keywordsDirective))
+	keywords, ok := token.LocalizedKeywords[name]
+	if !ok {
+		l.addError(line, col, fmt.Sprintf("unknown keywords %q", name))
+		return
+	}
+	l.keywords = keywords
+}
+
 func (l *Lexer) readChar() {
 	var size int
 	if l.readPos >= len(l.input) {
@@ -158,14 +273,29 @@ func isLetter(ch rune) bool {
 	return unicode.IsLetter(ch) || isEmoji(ch)
 }
 
+// isDigit reports whether ch is an ASCII digit, identifiers can contain other digits too
 func isDigit(ch rune) bool {
-	return unicode.IsDigit(ch)
+	return '0' <= ch && ch <= '9'
+}
+
+// numeralZeros are the zeros of the scripts numbers can be written in:
+// ASCII, Arabic-Indic (٠١٢٣) and Eastern Arabic-Indic (۰۱۲۳) digits
+var numeralZeros = []rune{'0', '\u0660', '\u06F0'}
+
+// numeralZero returns the zero of the script of the digit ch
+func numeralZero(ch rune) (rune, bool) {
+	for _, zero := range numeralZeros {
+		if zero <= ch && ch <= zero+9 {
+			return zero, true
+		}
+	}
+	return 0, false
 }
 
 func (l *Lexer) readIdentifier() string {
 	pos := l.pos
 	if isLetter(l.ch) || l.ch == '_' {
-		for isLetter(l.ch) || isDigit(l.ch) || l.ch == '_' {
+		for isLetter(l.ch) || unicode.IsDigit(l.ch) || l.ch == '_' {
 			l.readChar()
 		}
 	}
@@ -178,24 +308,217 @@ var escapeCharacters = map[rune]string{
 	'\\': "\\",
 	'"':  `"`,
 	't':  "\t",
+	'$':  "$",
+}
+
+// readString reads a double-quoted string literal
+func (l *Lexer) readString() (string, []token.Segment) {
+	line, col := l.line, l.col
+	l.readChar()
+	str, segments := l.readStringContent(func() bool { return l.ch == '"' || l.ch == 0 })
+	if l.ch == 0 {
+		l.addError(line, col, "unterminated string")
+	}
+	l.readChar()
+	return str, segments
+}
+
+// readRawString reads a string between backticks, which can span lines and has no escape sequences
+func (l *Lexer) readRawString() string {
+	line, col := l.line, l.col
+	l.readChar()
+	pos := l.pos
+	for l.ch != '`' && l.ch != 0 {
+		l.readChar()
+	}
+	if l.ch == 0 {
+		l.addError(line, col, "unterminated raw string")
+	}
+	str := l.input[pos:l.pos]
+	l.readChar()
+	return str
 }
 
-func (l *Lexer) readString() string {
-	var str bytes.Buffer
+// readTripleQuotedString reads a string between triple quotes, which can span lines.
+// Its indentation is stripped before escape sequences and embedded expressions are read.
+func (l *Lexer) readTripleQuotedString() (string, []token.Segment) {
+	startLine, startCol := l.line, l.col
 	l.readChar()
-	for l.ch != '"' {
+	l.readChar()
+	l.readChar()
+	line := l.line
+	pos := l.pos
+	for !(l.ch == '"' && l.peekChar() == '"' && l.peekCharAt(1) == '"') && l.ch != 0 {
 		if l.ch == '\\' {
 			l.readChar()
-			if val, ok := escapeCharacters[l.ch]; ok {
-				str.WriteString(val)
+		}
+		l.readChar()
+	}
+	if l.ch == 0 {
+		l.addError(startLine, startCol, "unterminated string")
+	}
+	raw := l.input[pos:l.pos]
+	l.readChar()
+	l.readChar()
+	l.readChar()
+
+	if strings.HasPrefix(raw, "\n") {
+		raw = raw[1:]
+		line++
+	}
+	content := &Lexer{input: dedent(raw), line: line, col: 0}
+	content.readChar()
+	str, segments := content.readStringContent(func() bool { return content.ch == 0 })
+	l.errors = append(l.errors, content.errors...)
+	return str, segments
+}
+
+// dedent strips the indentation of the closing delimiter from every line if it is on a line of its own,
+// and the indentation common to all lines which are not blank otherwise
+func dedent(str string) string {
+	lines := strings.Split(str, "\n")
+	last := lines[len(lines)-1]
+	var indentation string
+	if strings.TrimLeft(last, " \t") == "" {
+		indentation = last
+		lines = lines[:len(lines)-1]
+	} else {
+		indentation = last[:len(last)-len(strings.TrimLeft(last, " \t"))]
+		for _, line := range lines {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			for !strings.HasPrefix(line, indentation) {
+				indentation = indentation[:len(indentation)-1]
 			}
+		}
+	}
+	for i, line := range lines {
+		if strings.HasPrefix(line, indentation) {
+			lines[i] = line[len(indentation):]
+		} else if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// readStringContent reads the content of a string up to end, replacing escape sequences.
+// The content is split into segments if it embeds expressions with ${...},
+// the literal of such a string is its source with the escape sequences replaced.
+func (l *Lexer) readStringContent(end func() bool) (string, []token.Segment) {
+	var str, literal bytes.Buffer
+	var segments []token.Segment
+	for !end() {
+		if l.ch == '\\' {
+			val := l.readEscape()
+			str.WriteString(val)
+			literal.WriteString(val)
+		} else if l.ch == '$' && l.peekChar() == '{' {
+			segments = append(segments, token.Segment{Text: str.String()})
+			str.Reset()
+			l.readChar()
+			// the position of the {, which the embedded expression follows
+			line, col := l.line, l.col
+			l.readChar()
+			src, ok := l.readInterpolation(line, col-1)
+			segments = append(segments, token.Segment{Text: src, Expression: true, Line: line, Col: col, Unterminated: !ok})
+			literal.WriteString("${" + src + "}")
+			continue
 		} else {
 			str.WriteRune(l.ch)
+			literal.WriteRune(l.ch)
 		}
 		l.readChar()
 	}
+	if segments == nil {
+		return str.String(), nil
+	}
+	return literal.String(), append(segments, token.Segment{Text: str.String()})
+}
+
+// readEscape reads an escape sequence starting at a backslash and leaves l.ch on its last character.
+// \xNN is the character with the hexadecimal code NN, and \u{N} the one with the code N of up to 6 digits.
+func (l *Lexer) readEscape() string {
+	line, col := l.line, l.col
 	l.readChar()
-	return str.String()
+	// the string is unterminated, which is reported by the caller
+	if l.ch == 0 {
+		return ""
+	}
+	if val, ok := escapeCharacters[l.ch]; ok {
+		return val
+	}
+	switch l.ch {
+	case 'x':
+		digits := ""
+		for len(digits) < 2 && isHexDigit(l.peekChar()) {
+			l.readChar()
+			digits += string(l.ch)
+		}
+		if len(digits) != 2 {
+			l.addError(line, col, fmt.Sprintf("invalid escape sequence \\x%s: expected 2 hexadecimal digits", digits))
+			return ""
+		}
+		code, _ := strconv.ParseUint(digits, 16, 8)
+		return string(rune(code))
+	case 'u':
+		if l.peekChar() != '{' {
+			l.addError(line, col, "invalid escape sequence \\u: expected \\u{...}")
+			return ""
+		}
+		l.readChar()
+		digits := ""
+		for isHexDigit(l.peekChar()) {
+			l.readChar()
+			digits += string(l.ch)
+		}
+		if l.peekChar() != '}' || len(digits) == 0 || len(digits) > 6 {
+			l.addError(line, col, fmt.Sprintf("invalid escape sequence \\u{%s: expected 1 to 6 hexadecimal digits and }", digits))
+			return ""
+		}
+		l.readChar()
+		code, _ := strconv.ParseUint(digits, 16, 32)
+		if !utf8.ValidRune(rune(code)) {
+			l.addError(line, col, fmt.Sprintf("invalid escape sequence \\u{%s}: not a valid code point", digits))
+			return ""
+		}
+		return string(rune(code))
+	}
+	l.addError(line, col, fmt.Sprintf("invalid escape sequence \\%s", printable(l.ch)))
+	return ""
+}
+
+// readInterpolation reads the source of an expression embedded in a string up to its closing brace,
+// braces and strings nested in the expression are skipped over. line and col locate the ${,
+// and ok is false if the closing brace is missing.
+func (l *Lexer) readInterpolation(line, col int) (src string, ok bool) {
+	pos := l.pos
+	depth := 0
+	for l.ch != 0 && (l.ch != '}' || depth > 0) {
+		switch l.ch {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '"':
+			l.readChar()
+			for l.ch != '"' && l.ch != 0 {
+				if l.ch == '\\' {
+					l.readChar()
+				}
+				l.readChar()
+			}
+		}
+		l.readChar()
+	}
+	if l.ch == 0 {
+		l.addError(line, col, "unterminated ${ in string")
+	}
+	src = l.input[pos:l.pos]
+	ok = l.ch != 0
+	l.readChar() // the closing brace
+	return src, ok
 }
 
 func (l *Lexer) skipWhiteSpace() {
@@ -204,25 +527,196 @@ func (l *Lexer) skipWhiteSpace() {
 	}
 }
 
+// isDocComment reports whether a comment after // is a doc comment, i.e. it started with exactly ///
+func isDocComment(comment string) bool {
+	return strings.HasPrefix(comment, "/") && !strings.HasPrefix(comment, "//")
+}
+
+// skipBlockComment skips a comment between /* and */, which can span lines but cannot be nested
+func (l *Lexer) skipBlockComment() {
+	line, col := l.line, l.col
+	l.readChar()
+	l.readChar()
+	for !(l.ch == '*' && l.peekChar() == '/') {
+		if l.ch == 0 {
+			l.addError(line, col, "unterminated block comment")
+			return
+		}
+		l.readChar()
+	}
+	l.readChar()
+	l.readChar()
+}
+
 func (l *Lexer) skipLine() {
 	for l.ch != '\n' && l.ch != 0 {
 		l.readChar()
 	}
 }
 
-func (l *Lexer) readNumber() string {
+// readNumber reads an integer, a float or a decimal, a dot is part of the number
+// only if it is followed by a digit so that 3.f() still calls f.
+// Numbers with errors are illegal, as the errors have been reported already.
+func (l *Lexer) readNumber() (token.Type, string) {
+	pos, errors := l.pos, len(l.errors)
+	tokenType, literal := l.readNumberLiteral()
+	if len(l.errors) > errors {
+		return token.Illegal, l.input[pos:l.pos]
+	}
+	return tokenType, literal
+}
+
+// readNumberLiteral reads a number whose digits are all of the same script,
+// its literal is written with ASCII digits so that it can be parsed
+func (l *Lexer) readNumberLiteral() (token.Type, string) {
 	pos := l.pos
-	for isDigit(l.ch) {
+	tokenType := token.Type(token.Int)
+	if base, ok := basePrefixes[rune(l.peekChar())]; ok && l.ch == '0' {
+		l.readPrefixedDigits(base)
+		return tokenType, l.input[pos:l.pos]
+	}
+	zero, _ := numeralZero(l.ch)
+	isNumeral := func(ch rune) bool {
+		return zero <= ch && ch <= zero+9
+	}
+	l.readDigits(isNumeral)
+	if l.ch == '.' && unicode.IsDigit(l.peekRune()) {
+		tokenType = token.Float
+		l.readChar()
+		l.readDigits(isNumeral)
+	}
+	// a d suffix makes a decimal, e.g. 12.34d
+	if l.ch == 'd' && !isLetter(l.peekRune()) && !unicode.IsDigit(l.peekRune()) && l.peekChar() != '_' {
+		tokenType = token.Decimal
 		l.readChar()
 	}
+	if unicode.IsDigit(l.ch) {
+		l.addError(l.line, l.col, "numbers cannot mix digits of different scripts")
+		for unicode.IsDigit(l.ch) {
+			l.readChar()
+		}
+	}
+	return tokenType, toASCIIDigits(l.input[pos:l.pos], zero)
+}
+
+// toASCIIDigits replaces the digits of the script starting at zero with ASCII digits
+func toASCIIDigits(str string, zero rune) string {
+	if zero == '0' {
+		return str
+	}
+	return strings.Map(func(ch rune) rune {
+		if zero <= ch && ch <= zero+9 {
+			return '0' + ch - zero
+		}
+		return ch
+	}, str)
+}
+
+// basePrefixes maps the letter after 0 in a prefixed integer to its base, e.g. 0xff
+var basePrefixes = map[rune]int{
+	'x': 16, 'X': 16,
+	'o': 8, 'O': 8,
+	'b': 2, 'B': 2,
+}
+
+var baseNames = map[int]string{
+	16: "hexadecimal",
+	8:  "octal",
+	2:  "binary",
+}
+
+// readDigits reads decimal digits, which can be separated by single underscores, e.g. 1_000_000
+func (l *Lexer) readDigits(isDigit func(rune) bool) {
+	for isDigit(l.ch) || l.ch == '_' {
+		if l.ch == '_' {
+			l.checkSeparator(isDigit)
+		}
+		l.readChar()
+	}
+}
+
+// readPrefixedDigits reads an integer in base after its prefix, e.g. 0xff.
+// Letters and digits which follow are read too, so that invalid digits are reported rather than split off.
+func (l *Lexer) readPrefixedDigits(base int) {
+	line, col := l.line, l.col
+	l.readChar()
+	l.readChar()
+	isBaseDigit := func(ch rune) bool {
+		return ch < unicode.MaxASCII && ch != '_' && strings.ContainsRune("0123456789abcdef"[:base], unicode.ToLower(ch))
+	}
+	digits, invalid := 0, 0
+	for isDigit(l.ch) || isLetter(l.ch) || l.ch == '_' {
+		switch {
+		case l.ch == '_':
+			l.checkSeparator(isBaseDigit)
+		case !isBaseDigit(l.ch):
+			l.addError(l.line, l.col, fmt.Sprintf("invalid digit '%s' in %s literal", printable(l.ch), baseNames[base]))
+			invalid++
+		default:
+			digits++
+		}
+		l.readChar()
+	}
+	if digits == 0 && invalid == 0 {
+		l.addError(line, col, fmt.Sprintf("%s literal has no digits", baseNames[base]))
+	}
+}
+
+// checkSeparator reports an underscore in a number which does not separate two digits,
+// a run of underscores is reported once
+func (l *Lexer) checkSeparator(isDigit func(rune) bool) {
+	previous, _ := utf8.DecodeLastRuneInString(l.input[:l.pos])
+	if previous == '_' {
+		return
+	}
+	if !isDigit(previous) || !isDigit(l.peekRune()) {
+		l.addError(l.line, l.col, "'_' must separate digits")
+	}
+}
+
+// readUnsupportedDigits reads digits of scripts which numbers cannot be written in, such as Devanagari
+func (l *Lexer) readUnsupportedDigits() string {
+	line, col := l.line, l.col
+	pos := l.pos
+	for unicode.IsDigit(l.ch) {
+		if _, ok := numeralZero(l.ch); ok {
+			break
+		}
+		l.readChar()
+	}
+	l.addError(line, col, fmt.Sprintf("numbers can only be written in ASCII, Arabic-Indic or Eastern Arabic-Indic digits; found %q", l.input[pos:l.pos]))
 	return l.input[pos:l.pos]
 }
 
 func (l *Lexer) peekChar() byte {
-	if l.readPos >= len(l.input) {
+	return l.peekCharAt(0)
+}
+
+// peekRune returns the character after the current one
+func (l *Lexer) peekRune() rune {
+	ch, _ := utf8.DecodeRuneInString(l.input[l.readPos:])
+	return ch
+}
+
+// peekCharAt returns the byte offset bytes after the next one
+func (l *Lexer) peekCharAt(offset int) byte {
+	if l.readPos+offset >= len(l.input) {
 		return 0
 	}
-	return l.input[l.readPos]
+	return l.input[l.readPos+offset]
+}
+
+// printable shows a character in an error, escaping it if it is not printable
+func printable(ch rune) string {
+	if unicode.IsPrint(ch) {
+		return string(ch)
+	}
+	quoted := strconv.QuoteRune(ch)
+	return quoted[1 : len(quoted)-1]
+}
+
+func isHexDigit(ch byte) bool {
+	return ('0' <= ch && ch <= '9') || ('a' <= ch && ch <= 'f') || ('A' <= ch && ch <= 'F')
 }
 
 func isEmoji(ch rune) bool {
@@ -1,6 +1,7 @@
 package lexer
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/latiif/lail/pkg/token"
@@ -13,7 +14,7 @@ let add = fn(x,y) {
   x+y;
 };
 let result = add(five,ten);
-!-/*5;
+!-/ *5;
 5 < 10 > 5;
 if (5 < 10) {
   return true;
@@ -150,7 +151,7 @@ _this_is_1_valid_identifier.
 
 func TestTokenCoordinates(t *testing.T) {
 	input :=
-		`
+		`x
 let myvar = 5;
 if "my long string"
 `
@@ -159,6 +160,7 @@ if "my long string"
 		expectedLine    int
 		expectedColumn  int
 	}{
+		{"x", 1, 1},
 		{"let", 2, 1},
 		{"myvar", 2, 5},
 		{"=", 2, 11},
@@ -185,3 +187,365 @@ if "my long string"
 		}
 	}
 }
+
+func TestNullSafeOperators(t *testing.T) {
+	input := `null ?? a?.b ? ?`
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.Null, "null"},
+		{token.Coalesce, "??"},
+		{token.Ident, "a"},
+		{token.OptionalDot, "?."},
+		{token.Ident, "b"},
+		{token.Illegal, "?"},
+		{token.Illegal, "?"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("test[%d] - token.Type wrong. got: %q, want: %q", i, tok.Type, tc.expectedType)
+		}
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("test[%d] - token.Literal wrong. got: %q, want: %q", i, tok.Literal, tc.expectedLiteral)
+		}
+	}
+}
+
+func TestInterpolatedStrings(t *testing.T) {
+	tests := []struct {
+		input            string
+		expectedType     token.Type
+		expectedLiteral  string
+		expectedSegments []token.Segment
+	}{
+		{`"plain"`, token.String, "plain", nil},
+		{`"no $ {interpolation} \${x}"`, token.String, "no $ {interpolation} ${x}", nil},
+		{`"x = ${x}!"`, token.InterpolatedString, "x = ${x}!", []token.Segment{
+			{Text: "x = "}, {Text: "x", Expression: true, Line: 1, Col: 7}, {Text: "!"},
+		}},
+		{`"${a}${b}"`, token.InterpolatedString, "${a}${b}", []token.Segment{
+			{Text: ""}, {Text: "a", Expression: true, Line: 1, Col: 3}, {Text: ""}, {Text: "b", Expression: true, Line: 1, Col: 7}, {Text: ""},
+		}},
+		{`"${f("}")} ${fn() { 1 }()}\n"`, token.InterpolatedString, "${f(\"}\")} ${fn() { 1 }()}\n", []token.Segment{
+			{Text: ""}, {Text: `f("}")`, Expression: true, Line: 1, Col: 3}, {Text: " "}, {Text: "fn() { 1 }()", Expression: true, Line: 1, Col: 13}, {Text: "\n"},
+		}},
+		{"\"a\n  ${\nx}\"", token.InterpolatedString, "a\n  ${\nx}", []token.Segment{
+			{Text: "a\n  "}, {Text: "\nx", Expression: true, Line: 2, Col: 4}, {Text: ""},
+		}},
+		{`"a ${x`, token.InterpolatedString, "a ${x}", []token.Segment{
+			{Text: "a "}, {Text: "x", Expression: true, Line: 1, Col: 5, Unterminated: true}, {Text: ""},
+		}},
+	}
+
+	for i, tc := range tests {
+		l := New(tc.input)
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("test[%d] - token.Type wrong. got: %q, want: %q", i, tok.Type, tc.expectedType)
+		}
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("test[%d] - token.Literal wrong. got: %q, want: %q", i, tok.Literal, tc.expectedLiteral)
+		}
+		if !reflect.DeepEqual(tok.Segments, tc.expectedSegments) {
+			t.Fatalf("test[%d] - token.Segments wrong. got: %+v, want: %+v", i, tok.Segments, tc.expectedSegments)
+		}
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Fatalf("test[%d] - expected EOF after the string. got: %q", i, next.Type)
+		}
+	}
+}
+
+func TestStringLiterals(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{"`raw \\n ${x} \"q\"`", token.String, `raw \n ${x} "q"`},
+		{"`two\nlines`", token.String, "two\nlines"},
+		{`"\u{1F319}\u{41}\x41\x7a"`, token.String, "🌙AAz"},
+		{`"\$\"\\"`, token.String, `$"\`},
+		{`"""inline "quoted" text"""`, token.String, `inline "quoted" text`},
+		{"\"\"\"\n    first\n      second\n    \"\"\"", token.String, "first\n  second"},
+		{"\"\"\"\n    first\n\n      second\n  \"\"\"", token.String, "  first\n\n    second"},
+		{"\"\"\"\n  a\n b\n  c\"\"\"", token.String, " a\nb\n c"},
+		{"\"\"\"\n  \\t${x}\n  \"\"\"", token.InterpolatedString, "\t${x}"},
+		{`""`, token.String, ""},
+	}
+
+	for i, tc := range tests {
+		l := New(tc.input)
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("test[%d] - token.Type wrong. got: %q, want: %q", i, tok.Type, tc.expectedType)
+		}
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("test[%d] - token.Literal wrong. got: %q, want: %q", i, tok.Literal, tc.expectedLiteral)
+		}
+		if len(l.Errors()) != 0 {
+			t.Fatalf("test[%d] - unexpected errors: %v", i, l.Errors())
+		}
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Fatalf("test[%d] - expected EOF after the string. got: %q", i, next.Type)
+		}
+	}
+}
+
+func TestInvalidEscapes(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedError string
+	}{
+		{`"\q"`, `Lexing error: At (1:2) invalid escape sequence \q`},
+		{`"ab\x4g"`, `Lexing error: At (1:4) invalid escape sequence \x4: expected 2 hexadecimal digits`},
+		{`"\u41"`, `Lexing error: At (1:2) invalid escape sequence \u: expected \u{...}`},
+		{`"\u{}"`, `Lexing error: At (1:2) invalid escape sequence \u{: expected 1 to 6 hexadecimal digits and }`},
+		{`"\u{1234567}"`, `Lexing error: At (1:2) invalid escape sequence \u{1234567: expected 1 to 6 hexadecimal digits and }`},
+		{`"\u{D800}"`, `Lexing error: At (1:2) invalid escape sequence \u{D800}: not a valid code point`},
+	}
+
+	for i, tc := range tests {
+		l := New(tc.input)
+		l.NextToken()
+		if len(l.Errors()) != 1 {
+			t.Fatalf("test[%d] - expected 1 error. got: %v", i, l.Errors())
+		}
+		if l.Errors()[0] != tc.expectedError {
+			t.Fatalf("test[%d] - error wrong. got: %q, want: %q", i, l.Errors()[0], tc.expectedError)
+		}
+	}
+}
+
+func TestLexerDiagnostics(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedErrors []string
+	}{
+		{`let x = "abc`, []string{"Lexing error: At (1:9) unterminated string"}},
+		{"let x = 1;\nlet y = `abc", []string{"Lexing error: At (2:9) unterminated raw string"}},
+		{`x = """abc"" `, []string{"Lexing error: At (1:5) unterminated string"}},
+		{`"a ${x`, []string{"Lexing error: At (1:4) unterminated ${ in string", "Lexing error: At (1:1) unterminated string"}},
+		{`"\`, []string{"Lexing error: At (1:1) unterminated string"}},
+		{"1 # 2\n  @", []string{"Lexing error: At (1:3) unexpected character '#'", "Lexing error: At (2:3) unexpected character '@'"}},
+		{"a ? b", []string{"Lexing error: At (1:3) unexpected character '?'"}},
+		{"\x01", []string{`Lexing error: At (1:1) unexpected character '\x01'`}},
+		{"\"\\\n\"", []string{`Lexing error: At (1:2) invalid escape sequence \\n`}},
+	}
+
+	for i, tc := range tests {
+		l := New(tc.input)
+		// lexing must terminate even when the input is invalid
+		for n := 0; l.NextToken().Type != token.EOF; n++ {
+			if n > len(tc.input) {
+				t.Fatalf("test[%d] - lexer did not reach EOF", i)
+			}
+		}
+		if !reflect.DeepEqual(l.Errors(), tc.expectedErrors) {
+			t.Errorf("test[%d] - errors wrong. got: %q, want: %q", i, l.Errors(), tc.expectedErrors)
+		}
+	}
+}
+
+func TestPrefixedNumbers(t *testing.T) {
+	input := `0xff 0XFF 0o17 0b1010 1_000_000 3.141_592 1_000.5d 0 x٣ 0xffd`
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.Int, "0xff"},
+		{token.Int, "0XFF"},
+		{token.Int, "0o17"},
+		{token.Int, "0b1010"},
+		{token.Int, "1_000_000"},
+		{token.Float, "3.141_592"},
+		{token.Decimal, "1_000.5d"},
+		{token.Int, "0"},
+		{token.Ident, "x٣"},
+		{token.Int, "0xffd"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("test[%d] - token.Type wrong. got: %q, want: %q", i, tok.Type, tc.expectedType)
+		}
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("test[%d] - token.Literal wrong. got: %q, want: %q", i, tok.Literal, tc.expectedLiteral)
+		}
+	}
+	if len(l.Errors()) != 0 {
+		t.Fatalf("unexpected errors: %v", l.Errors())
+	}
+}
+
+func TestInvalidNumbers(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedErrors []string
+	}{
+		{"1__0", []string{"Lexing error: At (1:2) '_' must separate digits"}},
+		{"1_", []string{"Lexing error: At (1:2) '_' must separate digits"}},
+		{"0x_ff", []string{"Lexing error: At (1:3) '_' must separate digits"}},
+		{"0x", []string{"Lexing error: At (1:1) hexadecimal literal has no digits"}},
+		{"0b102", []string{"Lexing error: At (1:5) invalid digit '2' in binary literal"}},
+		{"0o78", []string{"Lexing error: At (1:4) invalid digit '8' in octal literal"}},
+		{"१२", []string{`Lexing error: At (1:1) numbers can only be written in ASCII, Arabic-Indic or Eastern Arabic-Indic digits; found "१२"`}},
+		{"12٣", []string{"Lexing error: At (1:3) numbers cannot mix digits of different scripts"}},
+		{"٣.۴", []string{"Lexing error: At (1:3) numbers cannot mix digits of different scripts"}},
+	}
+
+	for i, tc := range tests {
+		l := New(tc.input)
+		tok := l.NextToken()
+		if tok.Type != token.Illegal {
+			if tok = l.NextToken(); tok.Type != token.Illegal {
+				t.Errorf("test[%d] - expected an illegal token. got: %q", i, tok.Type)
+			}
+		}
+		if !reflect.DeepEqual(l.Errors(), tc.expectedErrors) {
+			t.Errorf("test[%d] - errors wrong. got: %q, want: %q", i, l.Errors(), tc.expectedErrors)
+		}
+	}
+}
+
+func TestLocalizedNumerals(t *testing.T) {
+	input := `٣٤ ۴۵ ٣.١٤ ١_٠٠٠ ٢.٥d x١`
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.Int, "34"},
+		{token.Int, "45"},
+		{token.Float, "3.14"},
+		{token.Int, "1_000"},
+		{token.Decimal, "2.5d"},
+		{token.Ident, "x١"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("test[%d] - token.Type wrong. got: %q, want: %q", i, tok.Type, tc.expectedType)
+		}
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("test[%d] - token.Literal wrong. got: %q, want: %q", i, tok.Literal, tc.expectedLiteral)
+		}
+	}
+	if len(l.Errors()) != 0 {
+		t.Fatalf("unexpected errors: %v", l.Errors())
+	}
+}
+
+func TestLocalizedKeywords(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedTypes  []token.Type
+		expectedErrors []string
+	}{
+		{"ليكن إذا وإلا", []token.Type{token.Ident, token.Ident, token.Ident}, nil},
+		{"// keywords: ar\nليكن دالة إذا وإلا أرجع استورد صحيح خطأ عدم let", []token.Type{
+			token.Let, token.Function, token.If, token.Else, token.Return, token.Import, token.True, token.False, token.Null, token.Let,
+		}, nil},
+		{"// a comment\n//keywords:ar\nليكن", []token.Type{token.Let}, nil},
+		{"let\n// keywords: ar\nليكن", []token.Type{token.Let, token.Ident}, nil},
+		{"// keywords: xx\nليكن", []token.Type{token.Ident}, []string{`Lexing error: At (1:1) unknown keywords "xx"`}},
+	}
+
+	for i, tc := range tests {
+		l := New(tc.input)
+		for j, expected := range tc.expectedTypes {
+			if tok := l.NextToken(); tok.Type != expected {
+				t.Errorf("test[%d][%d] - token.Type wrong. got: %q, want: %q", i, j, tok.Type, expected)
+			}
+		}
+		if tok := l.NextToken(); tok.Type != token.EOF {
+			t.Errorf("test[%d] - expected EOF. got: %q", i, tok.Type)
+		}
+		if !reflect.DeepEqual(l.Errors(), tc.expectedErrors) {
+			t.Errorf("test[%d] - errors wrong. got: %q, want: %q", i, l.Errors(), tc.expectedErrors)
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	input := `3.14 42 1.f() 0.5.round() 7. 12.34d 5d 5do`
+	tests := []struct {
+		expectedType    token.Type
+		expectedLiteral string
+	}{
+		{token.Float, "3.14"},
+		{token.Int, "42"},
+		{token.Int, "1"},
+		{token.Dot, "."},
+		{token.Ident, "f"},
+		{token.Lparen, "("},
+		{token.Rparen, ")"},
+		{token.Float, "0.5"},
+		{token.Dot, "."},
+		{token.Ident, "round"},
+		{token.Lparen, "("},
+		{token.Rparen, ")"},
+		{token.Int, "7"},
+		{token.Dot, "."},
+		{token.Decimal, "12.34d"},
+		{token.Decimal, "5d"},
+		{token.Int, "5"},
+		{token.Ident, "do"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+	for i, tc := range tests {
+		tok := l.NextToken()
+		if tok.Type != tc.expectedType {
+			t.Fatalf("test[%d] - token.Type wrong. got: %q, want: %q", i, tok.Type, tc.expectedType)
+		}
+		if tok.Literal != tc.expectedLiteral {
+			t.Fatalf("test[%d] - token.Literal wrong. got: %q, want: %q", i, tok.Literal, tc.expectedLiteral)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedTypes  []token.Type
+		expectedDocs   []string
+		expectedErrors []string
+	}{
+		{"1 /* two\nlines */ + /**/ 2", []token.Type{token.Int, token.Plus, token.Int}, []string{"", "", ""}, nil},
+		{"1 /* not /* nested */ */", []token.Type{token.Int, token.Astersik, token.Slash}, []string{"", "", ""}, nil},
+		{"/// one\n///two\n/// three\nlet", []token.Type{token.Let}, []string{"one\ntwo\nthree"}, nil},
+		{"/// doc\n1 2", []token.Type{token.Int, token.Int}, []string{"doc", ""}, nil},
+		{"// plain\n//// plain\nlet", []token.Type{token.Let}, []string{""}, nil},
+		{"1 /* open", []token.Type{token.Int}, []string{""}, []string{"Lexing error: At (1:3) unterminated block comment"}},
+	}
+
+	for i, tc := range tests {
+		l := New(tc.input)
+		for j, expected := range tc.expectedTypes {
+			tok := l.NextToken()
+			if tok.Type != expected {
+				t.Errorf("test[%d][%d] - token.Type wrong. got: %q, want: %q", i, j, tok.Type, expected)
+			}
+			if tok.Doc != tc.expectedDocs[j] {
+				t.Errorf("test[%d][%d] - token.Doc wrong. got: %q, want: %q", i, j, tok.Doc, tc.expectedDocs[j])
+			}
+		}
+		if tok := l.NextToken(); tok.Type != token.EOF {
+			t.Errorf("test[%d] - expected EOF. got: %q", i, tok.Type)
+		}
+		if !reflect.DeepEqual(l.Errors(), tc.expectedErrors) {
+			t.Errorf("test[%d] - errors wrong. got: %q, want: %q", i, l.Errors(), tc.expectedErrors)
+		}
+	}
+}
@@ -26,3 +26,24 @@ func (a *Array) Inspect() string {
 
 	return out.String()
 }
+
+func (a *Array) Equals(other Object) bool {
+	o, ok := other.(*Array)
+	if !ok || len(a.Value) != len(o.Value) {
+		return false
+	}
+	for i, v := range a.Value {
+		if !v.Equals(o.Value[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *Array) HashKey() uint64 {
+	elements := make([][]byte, len(a.Value))
+	for i, v := range a.Value {
+		elements[i] = uint64Bytes(v.HashKey())
+	}
+	return hashKey(ArrayObject, elements...)
+}
@@ -13,3 +13,15 @@ func (b *Boolean) Inspect() string {
 func (b *Boolean) Type() ObjectType {
 	return BooleanObject
 }
+
+func (b *Boolean) Equals(other Object) bool {
+	o, ok := other.(*Boolean)
+	return ok && b.Value == o.Value
+}
+
+func (b *Boolean) HashKey() uint64 {
+	if b.Value {
+		return hashKey(BooleanObject, []byte{1})
+	}
+	return hashKey(BooleanObject, []byte{0})
+}
@@ -12,3 +12,12 @@ func (b *Builtin) Type() ObjectType {
 func (b *Builtin) Inspect() string {
 	return "Lail Builtin Function"
 }
+
+// Equals compares builtins by identity
+func (b *Builtin) Equals(other Object) bool {
+	return b == other
+}
+
+func (b *Builtin) HashKey() uint64 {
+	return identityKey(b)
+}
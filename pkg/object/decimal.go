@@ -0,0 +1,95 @@
+package object
+
+import (
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Decimal is an exact decimal number, its value is Unscaled * 10^-Scale
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int32
+}
+
+// ParseDecimal parses a decimal such as "-12.34"
+func ParseDecimal(str string) (*Decimal, bool) {
+	digits := str
+	scale := int32(0)
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		digits = str[:i] + str[i+1:]
+		scale = int32(len(str) - i - 1)
+	}
+	if strings.ContainsAny(digits, "+_") || strings.HasPrefix(strings.TrimPrefix(digits, "-"), "0x") {
+		return nil, false
+	}
+	unscaled, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, false
+	}
+	return &Decimal{Unscaled: unscaled, Scale: scale}, true
+}
+
+func (d *Decimal) Type() ObjectType {
+	return DecimalObject
+}
+
+func (d *Decimal) Inspect() string {
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	sign := ""
+	if d.Unscaled.Sign() < 0 {
+		sign = "-"
+	}
+	if d.Scale <= 0 {
+		return sign + digits + strings.Repeat("0", int(-d.Scale))
+	}
+	if len(digits) <= int(d.Scale) {
+		digits = strings.Repeat("0", int(d.Scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(d.Scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Equals compares decimals by value regardless of their scale, so 1.0d equals 1.00d
+func (d *Decimal) Equals(other Object) bool {
+	o, ok := other.(*Decimal)
+	if !ok {
+		return numbersEqual(d, other)
+	}
+	lhs, rhs := d.normalize(), o.normalize()
+	return lhs.Scale == rhs.Scale && lhs.Unscaled.Cmp(rhs.Unscaled) == 0
+}
+
+// HashKey hashes integral decimals like the integers they equal, and other
+// decimals, which only equal decimals, by their normalized digits
+func (d *Decimal) HashKey() uint64 {
+	normalized := d.normalize()
+	if normalized.Scale > 0 {
+		return hashKey(DecimalObject, []byte(normalized.Unscaled.String()), uint64Bytes(uint64(normalized.Scale)))
+	}
+	// an integer with more than 309 digits is beyond the largest float
+	if len(new(big.Int).Abs(normalized.Unscaled).String())-int(normalized.Scale) > 309 {
+		return numberKey(math.Inf(normalized.Unscaled.Sign()))
+	}
+	exp := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-normalized.Scale)), nil)
+	return NewBigInteger(exp.Mul(exp, normalized.Unscaled)).HashKey()
+}
+
+// normalize removes trailing zeros, giving the representation with the smallest scale
+func (d *Decimal) normalize() *Decimal {
+	if d.Unscaled.Sign() == 0 {
+		return &Decimal{Unscaled: new(big.Int), Scale: 0}
+	}
+	ten := big.NewInt(10)
+	unscaled, scale := new(big.Int).Set(d.Unscaled), d.Scale
+	quotient, remainder := new(big.Int), new(big.Int)
+	for {
+		quotient.QuoRem(unscaled, ten, remainder)
+		if remainder.Sign() != 0 {
+			break
+		}
+		unscaled.Set(quotient)
+		scale--
+	}
+	return &Decimal{Unscaled: unscaled, Scale: scale}
+}
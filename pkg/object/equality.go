@@ -0,0 +1,70 @@
+package object
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/big"
+)
+
+// hashKey hashes the type of an object together with the data describing its value
+func hashKey(t ObjectType, data ...[]byte) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(t))
+	for _, d := range data {
+		h.Write(d)
+	}
+	return h.Sum64()
+}
+
+// identityKey hashes an object which is only equal to itself by its address
+func identityKey(obj Object) uint64 {
+	return hashKey(obj.Type(), []byte(fmt.Sprintf("%p", obj)))
+}
+
+// uint64Bytes encodes an integer so that it can be hashed
+func uint64Bytes(val uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, val)
+	return buf
+}
+
+// numberKey hashes a number by its value as a float. Integers, floats and
+// integral decimals all hash this way, since numbers which are equal share it.
+func numberKey(val float64) uint64 {
+	// 0.0 and -0.0 are equal but differ in their bits
+	if val == 0 {
+		val = 0
+	}
+	return hashKey(IntegerObject, uint64Bytes(math.Float64bits(val)))
+}
+
+// numbersEqual compares numbers of different types the way arithmetic mixes them:
+// integers and floats are compared as floats, integers and decimals exactly,
+// and floats and decimals, which are never mixed, are not equal
+func numbersEqual(lhs, rhs Object) bool {
+	switch lhs := lhs.(type) {
+	case *Integer:
+		switch rhs := rhs.(type) {
+		case *Float:
+			return lhs.asFloat() == rhs.Value
+		case *Decimal:
+			return (&Decimal{Unscaled: lhs.BigValue(), Scale: 0}).Equals(rhs)
+		}
+	case *Float, *Decimal:
+		if _, ok := rhs.(*Integer); ok {
+			return numbersEqual(rhs, lhs)
+		}
+	}
+	return false
+}
+
+// asFloat converts the integer to the nearest float
+func (i *Integer) asFloat() float64 {
+	if i.Big != nil {
+		val, _ := new(big.Float).SetInt(i.Big).Float64()
+		return val
+	}
+	return float64(i.Value)
+}
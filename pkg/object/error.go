@@ -1,13 +1,35 @@
 package object
 
+import "fmt"
+
+// ArithmeticError is the kind of errors raised by arithmetic, such as division by zero
+const ArithmeticError = "ArithmeticError"
+
 type Error struct {
 	Message string
+	// Kind classifies the error, it is empty for general errors
+	Kind string
+	// Line and Col locate the error in the source, they are 0 when unknown
+	Line int
+	Col  int
 }
 
 func (e *Error) Inspect() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s (line %d, column %d)", e.Message, e.Line, e.Col)
+	}
 	return e.Message
 }
 
 func (e *Error) Type() ObjectType {
 	return ErrorObject
 }
+
+func (e *Error) Equals(other Object) bool {
+	o, ok := other.(*Error)
+	return ok && e.Message == o.Message && e.Kind == o.Kind
+}
+
+func (e *Error) HashKey() uint64 {
+	return hashKey(ErrorObject, []byte(e.Kind), []byte(e.Message))
+}
@@ -0,0 +1,44 @@
+package object
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+)
+
+// File represents a handle to an open file
+type File struct {
+	Path   string
+	Handle *os.File
+	Reader *bufio.Reader
+	Closed bool
+}
+
+// NewFile wraps an opened file in a File object
+func NewFile(path string, handle *os.File) *File {
+	return &File{
+		Path:   path,
+		Handle: handle,
+		Reader: bufio.NewReader(handle),
+	}
+}
+
+func (f *File) Type() ObjectType {
+	return FileObject
+}
+
+func (f *File) Inspect() string {
+	if f.Closed {
+		return fmt.Sprintf("<closed file %s>", f.Path)
+	}
+	return fmt.Sprintf("<file %s>", f.Path)
+}
+
+// Equals compares files by identity, as each open file is a separate handle
+func (f *File) Equals(other Object) bool {
+	return f == other
+}
+
+func (f *File) HashKey() uint64 {
+	return identityKey(f)
+}
@@ -0,0 +1,36 @@
+package object
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Float struct {
+	Value float64
+}
+
+// Inspect always shows a decimal point or an exponent, so that floats can be told apart from integers
+func (f *Float) Inspect() string {
+	str := strconv.FormatFloat(f.Value, 'g', -1, 64)
+	if strings.ContainsAny(str, ".eIN") {
+		return str
+	}
+	return str + ".0"
+}
+
+func (f *Float) Type() ObjectType {
+	return FloatObject
+}
+
+// Equals follows IEEE 754, so NaN is not equal to itself
+func (f *Float) Equals(other Object) bool {
+	o, ok := other.(*Float)
+	if !ok {
+		return numbersEqual(f, other)
+	}
+	return f.Value == o.Value
+}
+
+func (f *Float) HashKey() uint64 {
+	return numberKey(f.Value)
+}
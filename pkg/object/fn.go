@@ -9,10 +9,12 @@ import (
 
 // Function represents a Function object
 type Function struct {
-	Name   *ast.Identifier
-	Params []*ast.Identifier
-	Body   *ast.BlockStatement
-	Env    *Env
+	Name     *ast.Identifier
+	Params   []ast.Pattern
+	Defaults []ast.Expression
+	Rest     *ast.Identifier
+	Body     *ast.BlockStatement
+	Env      *Env
 }
 
 func (f *Function) Type() ObjectType {
@@ -21,10 +23,7 @@ func (f *Function) Type() ObjectType {
 
 func (f *Function) Inspect() string {
 	var out bytes.Buffer
-	params := []string{}
-	for _, p := range f.Params {
-		params = append(params, p.String())
-	}
+	params := ast.ParamStrings(f.Params, f.Defaults, f.Rest)
 	out.WriteString("fn")
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
@@ -33,3 +32,12 @@ func (f *Function) Inspect() string {
 	out.WriteString("\n}")
 	return out.String()
 }
+
+// Equals compares functions by identity, functions with the same source are different closures
+func (f *Function) Equals(other Object) bool {
+	return f == other
+}
+
+func (f *Function) HashKey() uint64 {
+	return identityKey(f)
+}
@@ -0,0 +1,77 @@
+package object
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Hash maps string keys to values, keys are kept in insertion order
+type Hash struct {
+	Keys  []string
+	Pairs map[string]Object
+}
+
+// NewHash instantiates an empty Hash
+func NewHash() *Hash {
+	return &Hash{
+		Keys:  []string{},
+		Pairs: make(map[string]Object),
+	}
+}
+
+// Get retrieves the value of key
+func (h *Hash) Get(key string) (Object, bool) {
+	val, ok := h.Pairs[key]
+	return val, ok
+}
+
+// Set assigns a value to key and returns the value
+func (h *Hash) Set(key string, val Object) Object {
+	if _, ok := h.Pairs[key]; !ok {
+		h.Keys = append(h.Keys, key)
+	}
+	h.Pairs[key] = val
+	return val
+}
+
+func (h *Hash) Type() ObjectType {
+	return HashObject
+}
+
+func (h *Hash) Inspect() string {
+	var out bytes.Buffer
+	pairs := make([]string, len(h.Keys))
+	out.WriteString("{")
+
+	for i, key := range h.Keys {
+		pairs[i] = key + ": " + h.Pairs[key].Inspect()
+	}
+	out.WriteString(strings.Join(pairs, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// Equals compares hashes by their pairs regardless of the order of their keys
+func (h *Hash) Equals(other Object) bool {
+	o, ok := other.(*Hash)
+	if !ok || len(h.Pairs) != len(o.Pairs) {
+		return false
+	}
+	for key, val := range h.Pairs {
+		otherVal, ok := o.Pairs[key]
+		if !ok || !val.Equals(otherVal) {
+			return false
+		}
+	}
+	return true
+}
+
+// HashKey combines the hashes of the pairs such that the order of the keys does not matter
+func (h *Hash) HashKey() uint64 {
+	var sum uint64
+	for key, val := range h.Pairs {
+		sum += hashKey(HashObject, []byte(key), uint64Bytes(val.HashKey()))
+	}
+	return hashKey(HashObject, uint64Bytes(sum))
+}
@@ -1,15 +1,54 @@
 package object
 
-import "fmt"
+import (
+	"fmt"
+	"math/big"
+)
 
+// Integer is an int64, promoted to an arbitrary-precision Big when it does not fit
 type Integer struct {
 	Value int64
+	Big   *big.Int // nil unless the integer does not fit in Value
+}
+
+// NewBigInteger creates an Integer from a big.Int, demoting it to an int64 when it fits
+func NewBigInteger(val *big.Int) *Integer {
+	if val.IsInt64() {
+		return &Integer{Value: val.Int64()}
+	}
+	return &Integer{Big: val}
+}
+
+// BigValue returns the value of the integer as a big.Int
+func (i *Integer) BigValue() *big.Int {
+	if i.Big != nil {
+		return i.Big
+	}
+	return big.NewInt(i.Value)
 }
 
 func (i *Integer) Inspect() string {
+	if i.Big != nil {
+		return i.Big.String()
+	}
 	return fmt.Sprintf("%d", i.Value)
 }
 
 func (i *Integer) Type() ObjectType {
 	return IntegerObject
 }
+
+func (i *Integer) Equals(other Object) bool {
+	o, ok := other.(*Integer)
+	if !ok {
+		return numbersEqual(i, other)
+	}
+	if i.Big == nil && o.Big == nil {
+		return i.Value == o.Value
+	}
+	return i.BigValue().Cmp(o.BigValue()) == 0
+}
+
+func (i *Integer) HashKey() uint64 {
+	return numberKey(i.asFloat())
+}
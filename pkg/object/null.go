@@ -6,3 +6,11 @@ func (n *Null) Type() ObjectType { return NullObject }
 func (n *Null) Inspect() string {
 	return "null"
 }
+
+func (n *Null) Equals(other Object) bool {
+	return other.Type() == NullObject
+}
+
+func (n *Null) HashKey() uint64 {
+	return hashKey(NullObject)
+}
@@ -7,10 +7,17 @@ type BuiltinFunction func(args ...Object) Object
 type Object interface {
 	Type() ObjectType
 	Inspect() string
+	// Equals compares values by their contents, and functions and handles by identity.
+	// Numbers are compared by value as in arithmetic, other objects of different types are never equal.
+	Equals(other Object) bool
+	// HashKey returns a hash of the object, objects which are equal have the same hash
+	HashKey() uint64
 }
 
 const (
 	IntegerObject  = "Integer"
+	FloatObject    = "Float"
+	DecimalObject  = "Decimal"
 	BooleanObject  = "Boolean"
 	ArrayObject    = "Array"
 	NullObject     = "Null"
@@ -19,4 +26,9 @@ const (
 	StringObject   = "String"
 	ErrorObject    = "Error"
 	BuiltinObject  = "BuiltinObject"
+	FileObject     = "File"
+	HashObject     = "Hash"
+	RegexObject    = "Regex"
+	TimeObject     = "Time"
+	DurationObject = "Duration"
 )
@@ -0,0 +1,26 @@
+package object
+
+import "regexp"
+
+// Regex represents a compiled regular expression
+type Regex struct {
+	Value *regexp.Regexp
+}
+
+func (r *Regex) Type() ObjectType {
+	return RegexObject
+}
+
+func (r *Regex) Inspect() string {
+	return "/" + r.Value.String() + "/"
+}
+
+// Equals compares regular expressions by their pattern
+func (r *Regex) Equals(other Object) bool {
+	o, ok := other.(*Regex)
+	return ok && r.Value.String() == o.Value.String()
+}
+
+func (r *Regex) HashKey() uint64 {
+	return hashKey(RegexObject, []byte(r.Value.String()))
+}
@@ -0,0 +1,129 @@
+package object
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Repr returns the debug representation of obj, which shows values the way they are written in a program.
+// Unlike Inspect, strings are quoted and escaped, so that ["a, b"] and ["a", "b"] can be told apart.
+func Repr(obj Object) string {
+	switch obj := obj.(type) {
+	case *String:
+		return quote(obj.Value)
+	case *Decimal:
+		return obj.Inspect() + "d"
+	case *Array:
+		elements := make([]string, len(obj.Value))
+		for i, v := range obj.Value {
+			elements[i] = Repr(v)
+		}
+		return "[" + strings.Join(elements, ", ") + "]"
+	case *Hash:
+		pairs := make([]string, len(obj.Keys))
+		for i, key := range obj.Keys {
+			pairs[i] = quote(key) + ": " + Repr(obj.Pairs[key])
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	case *Return:
+		return Repr(obj.Value)
+	default:
+		return obj.Inspect()
+	}
+}
+
+// quote returns str as a string literal which reads back as str, escaping the characters
+// which would end it, start an embedded expression or are not printable
+func quote(str string) string {
+	var out strings.Builder
+	out.WriteByte('"')
+	for i, r := range str {
+		switch {
+		case r == '"' || r == '\\':
+			out.WriteString(`\` + string(r))
+		case r == '$' && strings.HasPrefix(str[i+1:], "{"):
+			out.WriteString(`\$`)
+		case r == '\n':
+			out.WriteString(`\n`)
+		case r == '\r':
+			out.WriteString(`\r`)
+		case r == '\t':
+			out.WriteString(`\t`)
+		case strconv.IsPrint(r):
+			out.WriteRune(r)
+		case r < utf8.RuneSelf:
+			fmt.Fprintf(&out, `\x%02x`, r)
+		default:
+			fmt.Fprintf(&out, `\u{%x}`, r)
+		}
+	}
+	out.WriteByte('"')
+	return out.String()
+}
+
+// Pretty returns the debug representation of obj indented over several lines.
+// Arrays and hashes are kept on one line when they fit in width columns, and split one element per line otherwise.
+func Pretty(obj Object, width int) string {
+	var out bytes.Buffer
+	pretty(&out, obj, 0, 0, 0, width)
+	return out.String()
+}
+
+// prettyIndent is the indentation of each level of nesting
+const prettyIndent = "  "
+
+// pretty writes obj starting at column and followed by trailing columns, such as the comma after an element.
+// Its elements are indented one level deeper than indent.
+func pretty(out *bytes.Buffer, obj Object, indent int, column int, trailing int, width int) {
+	flat := Repr(obj)
+	if column+utf8.RuneCountInString(flat)+trailing <= width {
+		out.WriteString(flat)
+		return
+	}
+	inner := strings.Repeat(prettyIndent, indent+1)
+	switch obj := obj.(type) {
+	case *Array:
+		if len(obj.Value) == 0 {
+			break
+		}
+		out.WriteString("[\n")
+		for i, v := range obj.Value {
+			out.WriteString(inner)
+			pretty(out, v, indent+1, len(inner), separatorWidth(i, len(obj.Value)), width)
+			if i < len(obj.Value)-1 {
+				out.WriteString(",")
+			}
+			out.WriteString("\n")
+		}
+		out.WriteString(strings.Repeat(prettyIndent, indent) + "]")
+		return
+	case *Hash:
+		if len(obj.Keys) == 0 {
+			break
+		}
+		out.WriteString("{\n")
+		for i, key := range obj.Keys {
+			prefix := inner + quote(key) + ": "
+			out.WriteString(prefix)
+			pretty(out, obj.Pairs[key], indent+1, utf8.RuneCountInString(prefix), separatorWidth(i, len(obj.Keys)), width)
+			if i < len(obj.Keys)-1 {
+				out.WriteString(",")
+			}
+			out.WriteString("\n")
+		}
+		out.WriteString(strings.Repeat(prettyIndent, indent) + "}")
+		return
+	}
+	out.WriteString(flat)
+}
+
+// separatorWidth is the width of the comma written after the element at index i of n elements
+func separatorWidth(i int, n int) int {
+	if i < n-1 {
+		return 1
+	}
+	return 0
+}
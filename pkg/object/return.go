@@ -11,3 +11,12 @@ func (r *Return) Inspect() string {
 func (r *Return) Type() ObjectType {
 	return ReturnObject
 }
+
+func (r *Return) Equals(other Object) bool {
+	o, ok := other.(*Return)
+	return ok && r.Value.Equals(o.Value)
+}
+
+func (r *Return) HashKey() uint64 {
+	return hashKey(ReturnObject, uint64Bytes(r.Value.HashKey()))
+}
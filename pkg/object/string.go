@@ -11,3 +11,12 @@ func (s *String) Inspect() string {
 func (s *String) Type() ObjectType {
 	return StringObject
 }
+
+func (s *String) Equals(other Object) bool {
+	o, ok := other.(*String)
+	return ok && s.Value == o.Value
+}
+
+func (s *String) HashKey() uint64 {
+	return hashKey(StringObject, []byte(s.Value))
+}
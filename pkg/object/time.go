@@ -0,0 +1,48 @@
+package object
+
+import "time"
+
+// Time represents an instant in time
+type Time struct {
+	Value time.Time
+}
+
+func (t *Time) Type() ObjectType {
+	return TimeObject
+}
+
+func (t *Time) Inspect() string {
+	return t.Value.Format(time.RFC3339Nano)
+}
+
+// Duration represents the time elapsed between two instants
+type Duration struct {
+	Value time.Duration
+}
+
+func (d *Duration) Type() ObjectType {
+	return DurationObject
+}
+
+func (d *Duration) Inspect() string {
+	return d.Value.String()
+}
+
+// Equals compares instants regardless of their location
+func (t *Time) Equals(other Object) bool {
+	o, ok := other.(*Time)
+	return ok && t.Value.Equal(o.Value)
+}
+
+func (t *Time) HashKey() uint64 {
+	return hashKey(TimeObject, uint64Bytes(uint64(t.Value.Unix())), uint64Bytes(uint64(t.Value.Nanosecond())))
+}
+
+func (d *Duration) Equals(other Object) bool {
+	o, ok := other.(*Duration)
+	return ok && d.Value == o.Value
+}
+
+func (d *Duration) HashKey() uint64 {
+	return hashKey(DurationObject, uint64Bytes(uint64(d.Value)))
+}
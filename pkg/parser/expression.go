@@ -2,9 +2,12 @@ package parser
 
 import (
 	"fmt"
+	"math/big"
 	"strconv"
+	"strings"
 
 	"github.com/latiif/lail/pkg/ast"
+	"github.com/latiif/lail/pkg/lexer"
 	"github.com/latiif/lail/pkg/token"
 )
 
@@ -12,6 +15,7 @@ const (
 	_ int = iota
 	Lowest
 	Assignment
+	Coalesce
 	Equals
 	LessGreater
 	Sum
@@ -21,19 +25,21 @@ const (
 )
 
 var precedences = map[token.Type]int{
-	token.Assign:   Assignment,
-	token.EQ:       Equals,
-	token.NEQ:      Equals,
-	token.LT:       LessGreater,
-	token.GT:       LessGreater,
-	token.GTE:      LessGreater,
-	token.LTE:      LessGreater,
-	token.Plus:     Sum,
-	token.Minus:    Sum,
-	token.Slash:    Product,
-	token.Astersik: Product,
-	token.Lparen:   Call,
-	token.Dot:      Product,
+	token.Assign:      Assignment,
+	token.Coalesce:    Coalesce,
+	token.EQ:          Equals,
+	token.NEQ:         Equals,
+	token.LT:          LessGreater,
+	token.GT:          LessGreater,
+	token.GTE:         LessGreater,
+	token.LTE:         LessGreater,
+	token.Plus:        Sum,
+	token.Minus:       Sum,
+	token.Slash:       Product,
+	token.Astersik:    Product,
+	token.Lparen:      Call,
+	token.Dot:         Product,
+	token.OptionalDot: Product,
 }
 
 func (p *Parser) parseExpressionStatement() *ast.ExpressionStatement {
@@ -72,6 +78,13 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{Token: p.currToken}
 
 	val, err := strconv.ParseInt(p.currToken.Literal, 0, 64)
+	// too large for an int64
+	if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+		if big, ok := new(big.Int).SetString(p.currToken.Literal, 0); ok {
+			lit.Big = big
+			return lit
+		}
+	}
 	if err != nil {
 		msg := fmt.Sprintf("could not parse %q as integer", p.currToken.Literal)
 		p.errors = append(p.errors, msg)
@@ -82,6 +95,27 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseFloatLiteral() ast.Expression {
+	lit := &ast.FloatLiteral{Token: p.currToken}
+
+	val, err := strconv.ParseFloat(p.currToken.Literal, 64)
+	if err != nil {
+		msg := fmt.Sprintf("could not parse %q as float", p.currToken.Literal)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+	lit.Value = val
+
+	return lit
+}
+
+func (p *Parser) parseDecimalLiteral() ast.Expression {
+	return &ast.DecimalLiteral{
+		Token: p.currToken,
+		Value: strings.Replace(strings.TrimSuffix(p.currToken.Literal, "d"), "_", "", -1),
+	}
+}
+
 func (p *Parser) parseStringLiteral() ast.Expression {
 	lit := &ast.StringLiteral{
 		Token: p.currToken,
@@ -106,6 +140,7 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 		Token:    p.currToken,
 		Operator: p.currToken.Literal,
 		Left:     left,
+		Strict:   p.strict,
 	}
 	precedence := p.currPrecedence()
 	p.nextToken()
@@ -140,6 +175,57 @@ func (p *Parser) parseBoolean() ast.Expression {
 	}
 }
 
+// parseInterpolatedString parses each expression embedded in a string with a parser of its own
+func (p *Parser) parseInterpolatedString() ast.Expression {
+	str := &ast.InterpolatedString{Token: p.currToken}
+	for _, segment := range p.currToken.Segments {
+		if !segment.Expression {
+			if segment.Text != "" {
+				str.Parts = append(str.Parts, &ast.StringLiteral{
+					Token: token.Token{Type: token.String, Literal: segment.Text, Line: p.currToken.Line, Col: p.currToken.Col},
+					Value: segment.Text,
+				})
+			}
+			continue
+		}
+		// the lexer has reported the missing brace already
+		if segment.Unterminated {
+			return nil
+		}
+		li := lexer.NewAt(segment.Text, segment.Line, segment.Col)
+		li.SetKeywords(p.l.Keywords())
+		embedded := New(li, p.Context)
+		embedded.strict = p.strict
+		program := embedded.ParseProgram()
+		// the embedded tokens are located in the source of the string, and so are the errors
+		p.errors = append(p.errors, embedded.Errors()...)
+		if len(embedded.Errors()) != 0 {
+			return nil
+		}
+		if len(program.Statements) != 1 {
+			p.errors = append(p.errors, fmt.Sprintf("Parsing error: At (%d:%d) Expected: %s Found: %q", segment.Line, segment.Col, "one expression", "${"+segment.Text+"}"))
+			return nil
+		}
+		stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+		if !ok {
+			p.errors = append(p.errors, fmt.Sprintf("Parsing error: At (%d:%d) Expected: %s Found: %q", segment.Line, segment.Col, "an expression", "${"+segment.Text+"}"))
+			return nil
+		}
+		str.Parts = append(str.Parts, stmt.Expression)
+	}
+	return str
+}
+
+// parseIllegal stands in for an illegal token, which the lexer has reported already,
+// with an identifier so that parsing continues without further errors
+func (p *Parser) parseIllegal() ast.Expression {
+	return &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+}
+
+func (p *Parser) parseNullLiteral() ast.Expression {
+	return &ast.NullLiteral{Token: p.currToken}
+}
+
 func (p *Parser) parseGroupedExpression() ast.Expression {
 	p.nextToken()
 
@@ -209,44 +295,55 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 func (p *Parser) parseFunctionLiteral() ast.Expression {
 	exp := &ast.FunctionLiteral{
 		Token: p.currToken,
+		Doc:   p.currToken.Doc,
 	}
 	if !p.expectPeek(token.Lparen) {
 		return nil
 	}
 
-	exp.Params = p.parseFunctionParams()
+	p.parseFunctionParams(exp)
 
 	exp.Body = p.parseBlockStatement()
 
 	return exp
 }
 
-func (p *Parser) parseFunctionParams() []*ast.Identifier {
-	params := []*ast.Identifier{}
+// parseFunctionParams parses parameters, each of which can have a default value, and a trailing ...rest, e.g. (x, y = 2, ...rest)
+func (p *Parser) parseFunctionParams(fl *ast.FunctionLiteral) {
+	fl.Params = []ast.Pattern{}
+	fl.Defaults = []ast.Expression{}
 
-	// empty params
-	if p.peekTokenIs(token.Rparen) {
+	for !p.peekTokenIs(token.Rparen) {
 		p.nextToken()
-		return params
-	}
-
-	p.nextToken()
-
-	param := &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
-	params = append(params, param)
-
-	for p.peekTokenIs(token.Comma) {
+		if p.currTokenIs(token.Ellipsis) {
+			// the rest can only be the last parameter
+			if !p.expectPeek(token.Ident) {
+				return
+			}
+			fl.Rest = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+			break
+		}
+		param := p.parsePattern()
+		if param == nil {
+			return
+		}
+		var def ast.Expression
+		if p.peekTokenIs(token.Assign) {
+			p.nextToken()
+			p.nextToken()
+			def = p.parseExpression(Lowest)
+		} else if len(fl.Defaults) > 0 && fl.Defaults[len(fl.Defaults)-1] != nil {
+			p.errors = append(p.errors, fmt.Sprintf("Parsing error: At (%d:%d) Expected: %s Found: %s", p.currToken.Line, p.currToken.Col, "a default value after parameters with default values", param.String()))
+		}
+		fl.Params = append(fl.Params, param)
+		fl.Defaults = append(fl.Defaults, def)
+		if !p.peekTokenIs(token.Comma) {
+			break
+		}
 		p.nextToken() // consume the comma
-		p.nextToken()
-		param := &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
-		params = append(params, param)
 	}
 
-	if !p.expectPeek(token.Rparen) {
-		return nil
-	}
-
-	return params
+	p.expectPeek(token.Rparen)
 }
 
 func (p *Parser) parseCallExpression(fnLiteral ast.Expression) ast.Expression {
@@ -254,7 +351,7 @@ func (p *Parser) parseCallExpression(fnLiteral ast.Expression) ast.Expression {
 		Token:    p.currToken,
 		Function: fnLiteral,
 	}
-	exp.Args = p.parseFunctionArgs()
+	exp.Args, exp.Named = p.parseFunctionArgs()
 	return exp
 }
 
@@ -268,33 +365,51 @@ func (p *Parser) parseInfixCallExpression(left ast.Expression) ast.Expression {
 	if ce, ok := callExpression.(*ast.CallExpression); ok {
 		exp.Function = ce.Function
 		exp.Args = append([]ast.Expression{left}, ce.Args...)
+		exp.Named = ce.Named
+	}
+	// obj.member without a call accesses a member
+	if id, ok := callExpression.(*ast.Identifier); ok {
+		return &ast.MemberExpression{
+			Token:  exp.Token,
+			Object: left,
+			Member: id,
+		}
 	}
 	return exp
 }
 
-func (p *Parser) parseFunctionArgs() []ast.Expression {
+// parseFunctionArgs parses the arguments of a call, arguments passed by name come after the others, e.g. (1, y: 2)
+func (p *Parser) parseFunctionArgs() ([]ast.Expression, []*ast.NamedArgument) {
 	args := []ast.Expression{}
+	var named []*ast.NamedArgument
 
-	if p.peekTokenIs(token.Rparen) {
+	for !p.peekTokenIs(token.Rparen) {
 		p.nextToken()
-		return args
-	}
-	p.nextToken()
-	arg := p.parseExpression(Lowest)
-	args = append(args, arg)
-
-	for p.peekTokenIs(token.Comma) {
+		if p.currTokenIs(token.Ident) && p.peekTokenIs(token.Colon) {
+			arg := &ast.NamedArgument{Token: p.currToken, Name: &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}}
+			p.nextToken()
+			p.nextToken()
+			arg.Value = p.parseExpression(Lowest)
+			named = append(named, arg)
+		} else {
+			tok := p.currToken
+			arg := p.parseExpression(Lowest)
+			if named != nil && arg != nil {
+				p.errors = append(p.errors, fmt.Sprintf("Parsing error: At (%d:%d) Expected: %s Found: %s", tok.Line, tok.Col, "a named argument after named arguments", arg.String()))
+			}
+			args = append(args, arg)
+		}
+		if !p.peekTokenIs(token.Comma) {
+			break
+		}
 		p.nextToken() // consume the comma
-		p.nextToken()
-		arg := p.parseExpression(Lowest)
-		args = append(args, arg)
 	}
 
 	if !p.expectPeek(token.Rparen) {
-		return nil
+		return nil, nil
 	}
 
-	return args
+	return args, named
 }
 
 func (p *Parser) parseArray() ast.Expression {
@@ -6,15 +6,22 @@ import (
 )
 
 func (p *Parser) parseLetStatement() *ast.LetStatement {
-	stmt := &ast.LetStatement{Token: p.currToken}
+	stmt := &ast.LetStatement{Token: p.currToken, Doc: p.currToken.Doc}
 
-	if !p.expectPeek(token.Ident) {
-		return nil
-	}
-
-	stmt.Name = &ast.Identifier{
-		Token: p.currToken,
-		Value: p.currToken.Literal,
+	if p.peekTokenIs(token.Lbracket) || p.peekTokenIs(token.Lbrace) {
+		p.nextToken()
+		if stmt.Pattern = p.parsePattern(); stmt.Pattern == nil {
+			return nil
+		}
+	} else {
+		if !p.expectPeek(token.Ident) {
+			return nil
+		}
+
+		stmt.Name = &ast.Identifier{
+			Token: p.currToken,
+			Value: p.currToken.Literal,
+		}
 	}
 
 	if !p.expectPeek(token.Assign) {
@@ -28,8 +35,11 @@ func (p *Parser) parseLetStatement() *ast.LetStatement {
 		p.nextToken()
 	}
 
-	if functionLiteral, ok := stmt.Value.(*ast.FunctionLiteral); ok {
+	if functionLiteral, ok := stmt.Value.(*ast.FunctionLiteral); ok && stmt.Name != nil {
 		functionLiteral.Name = stmt.Name
+		if functionLiteral.Doc == "" {
+			functionLiteral.Doc = stmt.Doc
+		}
 	}
 
 	return stmt
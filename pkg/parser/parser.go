@@ -25,6 +25,8 @@ type Parser struct {
 
 	Context string // Defines context (directory) for the parser
 
+	strict bool // whether the program starts with the strict pragma, which applies to its operators
+
 	errors []string
 }
 
@@ -39,11 +41,16 @@ func New(l *lexer.Lexer, context string) *Parser {
 	p.prefixParseFns = make(map[token.Type]prefixParseFn)
 	p.registerPrefix(token.Ident, p.parseIdentifier)
 	p.registerPrefix(token.Int, p.parseIntegerLiteral)
+	p.registerPrefix(token.Float, p.parseFloatLiteral)
+	p.registerPrefix(token.Decimal, p.parseDecimalLiteral)
 	p.registerPrefix(token.String, p.parseStringLiteral)
+	p.registerPrefix(token.InterpolatedString, p.parseInterpolatedString)
 	p.registerPrefix(token.Bang, p.parsePrefixExpression)
 	p.registerPrefix(token.Minus, p.parsePrefixExpression)
 	p.registerPrefix(token.True, p.parseBoolean)
 	p.registerPrefix(token.False, p.parseBoolean)
+	p.registerPrefix(token.Null, p.parseNullLiteral)
+	p.registerPrefix(token.Illegal, p.parseIllegal)
 	p.registerPrefix(token.Lparen, p.parseGroupedExpression)
 	p.registerPrefix(token.If, p.parseIfExpression)
 	p.registerPrefix(token.Function, p.parseFunctionLiteral)
@@ -62,6 +69,8 @@ func New(l *lexer.Lexer, context string) *Parser {
 	p.registerInfix(token.GTE, p.parseInfixExpression)
 	p.registerInfix(token.Lparen, p.parseCallExpression)
 	p.registerInfix(token.Dot, p.parseInfixCallExpression)
+	p.registerInfix(token.OptionalDot, p.parseInfixCallExpression)
+	p.registerInfix(token.Coalesce, p.parseInfixExpression)
 	p.registerInfix(token.Assign, p.parseAssignmentExpression)
 
 	p.nextToken() // set currToken
@@ -85,13 +94,30 @@ func (p *Parser) ParseProgram() *ast.Program {
 		stmt := p.parseStatement()
 		if stmt != nil {
 			program.Statements = append(program.Statements, stmt)
+			if len(program.Statements) == 1 && isStrictPragma(program.Statements) {
+				p.strict = true
+			}
 		}
 		p.nextToken()
 	}
+	program.Strict = isStrictPragma(program.Statements)
 
 	return program
 }
 
+// isStrictPragma reports whether the statements start with "use strict"
+func isStrictPragma(statements []ast.Statement) bool {
+	if len(statements) == 0 {
+		return false
+	}
+	stmt, ok := statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		return false
+	}
+	str, ok := stmt.Expression.(*ast.StringLiteral)
+	return ok && str.Value == ast.StrictPragma
+}
+
 func (p *Parser) parseStatement() ast.Statement {
 	switch p.currToken.Type {
 	case token.Let:
@@ -124,7 +150,9 @@ func (p *Parser) expectPeek(t token.Type) bool {
 
 // Errors return parsing errors
 func (p *Parser) Errors() []string {
-	return p.errors
+	// errors found by the lexer come first, as they often cause the parsing errors
+	errors := append([]string{}, p.l.Errors()...)
+	return append(errors, p.errors...)
 }
 
 func (p *Parser) peekError(t token.Type) {
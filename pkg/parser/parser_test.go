@@ -2,10 +2,13 @@ package parser
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/latiif/lail/pkg/ast"
 	"github.com/latiif/lail/pkg/lexer"
+	"github.com/latiif/lail/pkg/token"
 )
 
 func TestLetStatements(t *testing.T) {
@@ -162,6 +165,24 @@ func TestIntegerLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestFloatLiteralExpression(t *testing.T) {
+	input := "2.75;"
+
+	l := lexer.New(input)
+	p := New(l, "./")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	literal, ok := stmt.Expression.(*ast.FloatLiteral)
+	if !ok {
+		t.Fatalf("exp not *ast.FloatLiteral. got=%T", stmt.Expression)
+	}
+	if literal.Value != 2.75 {
+		t.Errorf("literal.Value not %g. got=%g", 2.75, literal.Value)
+	}
+}
+
 func TestParsingPrefixExpressions(t *testing.T) {
 	prefixTests := []struct {
 		input    string
@@ -368,6 +389,22 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"add(a + b + c * d / f + g)",
 			"add((((a + b) + ((c * d) / f)) + g))",
 		},
+		{
+			"a ?? b == c",
+			"(a ?? (b == c))",
+		},
+		{
+			"a ?? b ?? c + 1",
+			"((a ?? b) ?? (c + 1))",
+		},
+		{
+			"x = a ?? null",
+			"(x = (a ?? null))",
+		},
+		{
+			"a?.b ?? c",
+			"(a?.b ?? c)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -905,3 +942,303 @@ func TestParsingArrayLiteralsWithTrailingComma(t *testing.T) {
 	testInfixExpression(t, array.Elements[1], 2, "*", 2)
 	testInfixExpression(t, array.Elements[2], 3, "+", 3)
 }
+
+func TestMemberExpressionParsing(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedObject string
+		expectedMember string
+	}{
+		{"res.stdout", "res", "stdout"},
+		{"a.b.c", "a.b", "c"},
+		{"run(1).code", "run(1)", "code"},
+		{"1.add(2).code", "add(1, 2)", "code"},
+		{"res?.stdout", "res", "stdout"},
+		{"a?.b.c", "a?.b", "c"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l, "./")
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		exp, ok := stmt.Expression.(*ast.MemberExpression)
+		if !ok {
+			t.Fatalf("stmt.Expression is not ast.MemberExpression. got=%T",
+				stmt.Expression)
+		}
+		if exp.Object.String() != tt.expectedObject {
+			t.Errorf("object wrong. want=%q, got=%q", tt.expectedObject, exp.Object.String())
+		}
+		if !testIdentifier(t, exp.Member, tt.expectedMember) {
+			return
+		}
+	}
+}
+
+func TestBigIntegerLiteralExpression(t *testing.T) {
+	input := "123456789012345678901234567890;"
+
+	l := lexer.New(input)
+	p := New(l, "./")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+	if !ok {
+		t.Fatalf("exp not *ast.IntegerLiteral. got=%T", stmt.Expression)
+	}
+	if literal.Big == nil || literal.Big.String() != "123456789012345678901234567890" {
+		t.Errorf("literal.Big wrong. got=%v", literal.Big)
+	}
+}
+
+func TestInterpolatedStringParsing(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{`"x = ${x}"`, []string{"x = ", "x"}},
+		{`"${a + b * c}!"`, []string{"(a + (b * c))", "!"}},
+		{`"${xs.head()} and ${"${y}"}"`, []string{"head(xs)", " and ", "${y}"}},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l, "./")
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		str, ok := stmt.Expression.(*ast.InterpolatedString)
+		if !ok {
+			t.Fatalf("stmt.Expression is not ast.InterpolatedString. got=%T", stmt.Expression)
+		}
+		if len(str.Parts) != len(tt.expected) {
+			t.Fatalf("%s: wrong number of parts. want=%d, got=%d", tt.input, len(tt.expected), len(str.Parts))
+		}
+		for i, part := range str.Parts {
+			if part.String() != tt.expected[i] {
+				t.Errorf("%s: part %d wrong. want=%q, got=%q", tt.input, i, tt.expected[i], part.String())
+			}
+		}
+	}
+
+	for _, input := range []string{`"${}"`, `"${1; 2}"`, `"${let x = 1}"`, `"${1 +}"`} {
+		p := New(lexer.New(input), "./")
+		p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("%s: expected a parsing error", input)
+		}
+	}
+
+	// errors in embedded expressions are located in the source of the string, and a missing brace is reported once
+	errorTests := []struct {
+		input    string
+		expected []string
+	}{
+		{`out("${")`, []string{"Lexing error: At (1:6) unterminated ${ in string", "Lexing error: At (1:5) unterminated string", "Parsing error. At (1:13) Expected: ) Found: "}},
+		{`let s = "a ${1 + "b" $}";`, []string{"Lexing error: At (1:22) unexpected character '$'"}},
+		{"\"a\n  ${\n  [1, 2 3]}\"", []string{"Parsing error. At (3:9) Expected: ] Found: 3", "no prefix parse function for ] found"}},
+		{"\"a\n  ${\n  1; 2}\"", []string{`Parsing error: At (2:4) Expected: one expression Found: "${\n  1; 2}"`}},
+	}
+	for _, tt := range errorTests {
+		p := New(lexer.New(tt.input), "./")
+		p.ParseProgram()
+		if !reflect.DeepEqual(p.Errors(), tt.expected) {
+			t.Errorf("%q: wrong errors. want=%q, got=%q", tt.input, tt.expected, p.Errors())
+		}
+	}
+}
+
+func TestPrefixedIntegerLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0xff", 255},
+		{"0o17", 15},
+		{"0b1010", 10},
+		{"1_000_000", 1000000},
+		{"0x7fff_ffff_ffff_ffff", 9223372036854775807},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l, "./")
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.IntegerLiteral. got=%T", stmt.Expression)
+		}
+		if literal.Value != tt.expected {
+			t.Errorf("%s: literal.Value not %d. got=%d", tt.input, tt.expected, literal.Value)
+		}
+	}
+
+	for _, input := range []string{"0b12 + 1", "let x = 1__0;"} {
+		p := New(lexer.New(input), "./")
+		p.ParseProgram()
+		if len(p.Errors()) != 1 {
+			t.Errorf("%s: expected only the lexing error. got: %q", input, p.Errors())
+		}
+	}
+}
+
+func TestLexerErrors(t *testing.T) {
+	for _, input := range []string{`let x = "\q";`, `let x = "abc`, `let x = 1 # 2;`, `#`} {
+		p := New(lexer.New(input), "./")
+		p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Fatalf("%s: expected errors", input)
+		}
+		if !strings.HasPrefix(p.Errors()[0], "Lexing error") {
+			t.Errorf("%s: expected a lexing error first. got: %q", input, p.Errors()[0])
+		}
+		for _, err := range p.Errors() {
+			if strings.Contains(err, token.Illegal) {
+				t.Errorf("%s: illegal tokens should only be reported by the lexer. got: %q", input, err)
+			}
+		}
+	}
+}
+
+func TestStrictPragma(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{`"use strict"; 1 + 1`, true},
+		{`"use strict"`, true},
+		{`1 + 1; "use strict"`, false},
+		{`"use strictly"; 1 + 1`, false},
+		{``, false},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l, "./")
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		if program.Strict != tt.expected {
+			t.Errorf("%s: program.Strict wrong. want=%t, got=%t", tt.input, tt.expected, program.Strict)
+		}
+	}
+}
+
+func TestDocComments(t *testing.T) {
+	input := `
+/// Adds two numbers.
+///
+/// Both must be integers.
+let add = fn(a, b) { a + b };
+
+// not documentation
+let x = 1;
+
+/* a block comment */ let y = /// Doubles a number.
+fn(a) { a * 2 };
+
+//// not documentation either
+let z = 2;
+`
+	p := New(lexer.New(input), "./")
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	expected := []struct {
+		statement string
+		function  string
+	}{
+		{"Adds two numbers.\n\nBoth must be integers.", "Adds two numbers.\n\nBoth must be integers."},
+		{"", ""},
+		{"", "Doubles a number."},
+		{"", ""},
+	}
+	if len(program.Statements) != len(expected) {
+		t.Fatalf("expected %d statements. got: %d", len(expected), len(program.Statements))
+	}
+	for i, tt := range expected {
+		stmt := program.Statements[i].(*ast.LetStatement)
+		if stmt.Doc != tt.statement {
+			t.Errorf("statement[%d] - doc wrong. got: %q, want: %q", i, stmt.Doc, tt.statement)
+		}
+		if function, ok := stmt.Value.(*ast.FunctionLiteral); ok && function.Doc != tt.function {
+			t.Errorf("statement[%d] - function doc wrong. got: %q, want: %q", i, function.Doc, tt.function)
+		}
+	}
+}
+
+func TestDestructuringPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b, ...rest] = xs;", "let [a, b, ...rest] = xs;"},
+		{"let [a, [b, c]] = xs;", "let [a, [b, c]] = xs;"},
+		{"let [...all] = xs;", "let [...all] = xs;"},
+		{"let [] = xs;", "let [] = xs;"},
+		{"let {name, age} = person;", "let {name, age} = person;"},
+		{"let [first, {name}] = people;", "let [first, {name}] = people;"},
+		{"fn([a, b], {name}, c) { a }", "fn([a, b], {name}, c) a"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input), "./")
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		if program.String() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, program.String(), tt.expected)
+		}
+	}
+}
+
+func TestInvalidDestructuringPatterns(t *testing.T) {
+	for _, input := range []string{"let [a, ...rest, b] = xs;", "let [1] = xs;", "let {[a]} = xs;", "let [...] = xs;", "fn(1) { 1 }"} {
+		p := New(lexer.New(input), "./")
+		p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("%s: expected errors", input)
+		}
+	}
+}
+
+func TestFunctionParameterKinds(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"fn(x, y = 2) { x }", "fn(x, y = 2) x"},
+		{"fn(x = 1 + 2, ...rest) { x }", "fn(x = (1 + 2), ...rest) x"},
+		{"fn(...rest) { rest }", "fn(...rest) rest"},
+		{"fn([a, b] = [1, 2]) { a }", "fn([a, b] = [1,2]) a"},
+		{"f(1, y: 2, z: x + 1)", "f(1, y: 2, z: (x + 1))"},
+		{"f(x: 1)", "f(x: 1)"},
+		{"a.f(x: 1)", "f(a, x: 1)"},
+	}
+
+	for _, tt := range tests {
+		p := New(lexer.New(tt.input), "./")
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+		if program.String() != tt.expected {
+			t.Errorf("%s: got %q want %q", tt.input, program.String(), tt.expected)
+		}
+	}
+}
+
+func TestInvalidFunctionParameters(t *testing.T) {
+	for _, input := range []string{"fn(...rest, x) { x }", "fn(x = 1, y) { x }", "fn(...) { 1 }", "f(x: 1, 2)", "f(x: )"} {
+		p := New(lexer.New(input), "./")
+		p.ParseProgram()
+		if len(p.Errors()) == 0 {
+			t.Errorf("%s: expected errors", input)
+		}
+	}
+}
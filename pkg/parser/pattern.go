@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/latiif/lail/pkg/ast"
+	"github.com/latiif/lail/pkg/token"
+)
+
+// parsePattern parses what a value is bound to, an identifier, [a, b, ...rest] or {name, age}
+func (p *Parser) parsePattern() ast.Pattern {
+	switch p.currToken.Type {
+	case token.Ident:
+		return &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+	case token.Lbracket:
+		return p.parseArrayPattern()
+	case token.Lbrace:
+		return p.parseHashPattern()
+	}
+	p.errors = append(p.errors, fmt.Sprintf("Parsing error: At (%d:%d) Expected: %s Found: %s", p.currToken.Line, p.currToken.Col, "Identifier or destructuring pattern", p.currToken.Literal))
+	return nil
+}
+
+func (p *Parser) parseArrayPattern() ast.Pattern {
+	pattern := &ast.ArrayPattern{Token: p.currToken}
+
+	for !p.peekTokenIs(token.Rbracket) {
+		p.nextToken()
+		if p.currTokenIs(token.Ellipsis) {
+			// the rest can only be the last element
+			if !p.expectPeek(token.Ident) {
+				return nil
+			}
+			pattern.Rest = &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal}
+			break
+		}
+		element := p.parsePattern()
+		if element == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, element)
+		if !p.peekTokenIs(token.Comma) {
+			break
+		}
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.Rbracket) {
+		return nil
+	}
+	return pattern
+}
+
+func (p *Parser) parseHashPattern() ast.Pattern {
+	pattern := &ast.HashPattern{Token: p.currToken}
+
+	for !p.peekTokenIs(token.Rbrace) {
+		if !p.expectPeek(token.Ident) {
+			return nil
+		}
+		pattern.Keys = append(pattern.Keys, &ast.Identifier{Token: p.currToken, Value: p.currToken.Literal})
+		if !p.peekTokenIs(token.Comma) {
+			break
+		}
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.Rbrace) {
+		return nil
+	}
+	return pattern
+}
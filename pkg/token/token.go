@@ -9,13 +9,32 @@ type Token struct {
 	Literal string
 	Line    int
 	Col     int
+	// Segments are the parts of an interpolated string
+	Segments []Segment
+	// Doc is the text of the /// comments right before the token
+	Doc string
 }
 
-var keywords = map[string]Type{
+// Segment is a part of an interpolated string, either text or the source of an embedded expression
+type Segment struct {
+	Text       string
+	Expression bool
+	// Line and Col locate the { of an embedded expression, which its source follows
+	Line int
+	Col  int
+	// Unterminated is set for an embedded expression missing its closing brace
+	Unterminated bool
+}
+
+// Keywords maps the spellings of keywords to their types
+type Keywords map[string]Type
+
+var keywords = Keywords{
 	"fn":     Function,
 	"let":    Let,
 	"true":   True,
 	"false":  False,
+	"null":   Null,
 	"if":     If,
 	"else":   Else,
 	"import": Import,
@@ -31,6 +50,10 @@ const (
 	Ident = "IDENT" // add, foobar, x, y, ...
 	// Int is Integer, eg 21312, -235
 	Int = "INT"
+	// Float is a floating-point number, eg 3.14
+	Float = "FLOAT"
+	// Decimal is an exact decimal number, eg 12.34d
+	Decimal = "DECIMAL"
 	// Assign operator
 	Assign = "="
 	// Plus operator
@@ -47,6 +70,14 @@ const (
 	Comma = ","
 	// Dot .
 	Dot = "."
+	// Ellipsis ... precedes the identifier which binds the rest of an array
+	Ellipsis = "..."
+	// OptionalDot ?. is dot notation which evaluates to null when the value on its left is null
+	OptionalDot = "?."
+	// Coalesce operator ?? evaluates to its right hand side when its left hand side is null
+	Coalesce = "??"
+	// Colon : separates the name of a named argument from its value
+	Colon = ":"
 	// Semicolon ;
 	Semicolon = ";"
 	// Lparen is left parenthesis (
@@ -81,6 +112,8 @@ const (
 	True = "TRUE"
 	// False boolean literal
 	False = "FALSE"
+	// Null literal
+	Null = "NULL"
 	// If conditional
 	If = "IF"
 	// Else else-conditional
@@ -91,16 +124,37 @@ const (
 	DQuote = "\""
 	// String
 	String = "STRING"
+	// InterpolatedString is a string with embedded expressions, eg "x = ${x}"
+	InterpolatedString = "INTERPOLATED_STRING"
 	// Import keyword
 	Import = "IMPORT"
 	// One line comment marker
 	Comment = "//"
 )
 
-// LookupIdent looks up a string in keywords
-func LookupIdent(ident string) Type {
+// LocalizedKeywords are aliases of the keywords in other languages, a file selects them by name
+// with a comment before its first token, e.g. // keywords: ar
+var LocalizedKeywords = map[string]Keywords{
+	"ar": {
+		"دالة":   Function,
+		"ليكن":   Let,
+		"صحيح":   True,
+		"خطأ":    False,
+		"عدم":    Null,
+		"إذا":    If,
+		"وإلا":   Else,
+		"استورد": Import,
+		"أرجع":   Return,
+	},
+}
+
+// LookupIdent looks up a string in keywords, and then in the localized aliases if any were selected
+func LookupIdent(ident string, localized Keywords) Type {
 	if tok, ok := keywords[ident]; ok {
 		return tok
 	}
+	if tok, ok := localized[ident]; ok {
+		return tok
+	}
 	return Ident
 }